@@ -0,0 +1,86 @@
+// Package dataset provides Dataset & Loader which feeds mini-batches to trainer.
+package dataset
+
+import (
+	"github.com/po3rin/gonnp/matutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Dataset is data which returns samples picked by indices.
+// it lets trainer read mini-batches without keeping copies of whole data.
+type Dataset interface {
+	// Len returns number of samples.
+	Len() int
+	// Batch returns samples of indices as mini-batch.
+	// x is inputs passed to model, ex. a (N, D) matrix or a matrix per sample.
+	Batch(indices []int) (x []mat.Matrix, teacher mat.Matrix)
+}
+
+// Matrix is Dataset whose samples are rows of matrices.
+type Matrix struct {
+	X       *mat.Dense
+	Teacher *mat.Dense
+}
+
+// NewMatrix inits Matrix dataset. x & teacher are copied only if they are not *mat.Dense.
+func NewMatrix(x, teacher mat.Matrix) *Matrix {
+	xr, _ := x.Dims()
+	tr, _ := teacher.Dims()
+	if xr != tr {
+		panic("gonnp: number of rows of x & teacher are different")
+	}
+	return &Matrix{
+		X:       dense(x),
+		Teacher: dense(teacher),
+	}
+}
+
+// Len returns number of rows.
+func (m *Matrix) Len() int {
+	r, _ := m.X.Dims()
+	return r
+}
+
+// Batch returns rows of indices. x has one matrix.
+func (m *Matrix) Batch(indices []int) (x []mat.Matrix, teacher mat.Matrix) {
+	return []mat.Matrix{matutil.ThinRow(m.X, indices)}, matutil.ThinRow(m.Teacher, indices)
+}
+
+// Matrix3D is Dataset whose samples are matrices of X & rows of Teacher like contexts of SimpleCBOW.
+type Matrix3D struct {
+	X       []mat.Matrix
+	Teacher *mat.Dense
+}
+
+// NewMatrix3D inits Matrix3D dataset. x is not copied.
+func NewMatrix3D(x []mat.Matrix, teacher mat.Matrix) *Matrix3D {
+	tr, _ := teacher.Dims()
+	if len(x) != tr {
+		panic("gonnp: number of samples of x & rows of teacher are different")
+	}
+	return &Matrix3D{
+		X:       x,
+		Teacher: dense(teacher),
+	}
+}
+
+// Len returns number of samples.
+func (m *Matrix3D) Len() int {
+	return len(m.X)
+}
+
+// Batch returns samples of indices. x has a matrix per sample.
+func (m *Matrix3D) Batch(indices []int) (x []mat.Matrix, teacher mat.Matrix) {
+	x = make([]mat.Matrix, len(indices))
+	for i, id := range indices {
+		x[i] = m.X[id]
+	}
+	return x, matutil.ThinRow(m.Teacher, indices)
+}
+
+func dense(x mat.Matrix) *mat.Dense {
+	if d, ok := x.(*mat.Dense); ok {
+		return d
+	}
+	return mat.DenseCopyOf(x)
+}
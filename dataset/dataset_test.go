@@ -0,0 +1,112 @@
+// +build !e2e
+
+package dataset_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/dataset"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMatrixBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		x           mat.Matrix
+		teacher     mat.Matrix
+		indices     []int
+		wantX       mat.Matrix
+		wantTeacher mat.Matrix
+	}{
+		{
+			name:        "sequential",
+			x:           mat.NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6}),
+			teacher:     mat.NewDense(3, 1, []float64{0, 1, 2}),
+			indices:     []int{0, 1},
+			wantX:       mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+			wantTeacher: mat.NewDense(2, 1, []float64{0, 1}),
+		},
+		{
+			name:        "shuffled",
+			x:           mat.NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6}),
+			teacher:     mat.NewDense(3, 1, []float64{0, 1, 2}),
+			indices:     []int{2, 0},
+			wantX:       mat.NewDense(2, 2, []float64{5, 6, 1, 2}),
+			wantTeacher: mat.NewDense(2, 1, []float64{2, 0}),
+		},
+		{
+			name:        "not dense",
+			x:           mat.NewDense(2, 3, []float64{1, 3, 5, 2, 4, 6}).T(),
+			teacher:     mat.NewVecDense(3, []float64{0, 1, 2}),
+			indices:     []int{1},
+			wantX:       mat.NewDense(1, 2, []float64{3, 4}),
+			wantTeacher: mat.NewDense(1, 1, []float64{1}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ds := dataset.NewMatrix(tt.x, tt.teacher)
+			if ds.Len() != 3 {
+				t.Errorf("len: want = 3, got = %v", ds.Len())
+			}
+			x, teacher := ds.Batch(tt.indices)
+			if len(x) != 1 {
+				t.Fatalf("x: want 1 matrix, got = %v", len(x))
+			}
+			if !mat.Equal(x[0], tt.wantX) {
+				t.Errorf("x: want = %v, got = %v", tt.wantX, x)
+			}
+			if !mat.Equal(teacher, tt.wantTeacher) {
+				t.Errorf("teacher: want = %v, got = %v", tt.wantTeacher, teacher)
+			}
+		})
+	}
+}
+
+func TestNewMatrixPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	dataset.NewMatrix(mat.NewDense(3, 2, nil), mat.NewDense(2, 1, nil))
+}
+
+func TestMatrix3DBatch(t *testing.T) {
+	x := []mat.Matrix{
+		mat.NewDense(2, 2, []float64{1, 0, 0, 1}),
+		mat.NewDense(2, 2, []float64{2, 0, 0, 2}),
+		mat.NewDense(2, 2, []float64{3, 0, 0, 3}),
+	}
+	teacher := mat.NewDense(3, 1, []float64{0, 1, 2})
+
+	ds := dataset.NewMatrix3D(x, teacher)
+	if ds.Len() != 3 {
+		t.Errorf("len: want = 3, got = %v", ds.Len())
+	}
+
+	gotX, gotTeacher := ds.Batch([]int{2, 0})
+	wantX := []mat.Matrix{x[2], x[0]}
+	if len(gotX) != len(wantX) {
+		t.Fatalf("x: want = %v, got = %v", len(wantX), len(gotX))
+	}
+	for i := range wantX {
+		if !mat.Equal(gotX[i], wantX[i]) {
+			t.Errorf("x[%v]: want = %v, got = %v", i, wantX[i], gotX[i])
+		}
+	}
+	if want := mat.NewDense(2, 1, []float64{2, 0}); !mat.Equal(gotTeacher, want) {
+		t.Errorf("teacher: want = %v, got = %v", want, gotTeacher)
+	}
+}
+
+func TestNewMatrix3DPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	dataset.NewMatrix3D([]mat.Matrix{mat.NewDense(1, 1, nil)}, mat.NewDense(2, 1, nil))
+}
@@ -0,0 +1,165 @@
+package dataset
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/po3rin/gonnp/matutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Loader iterates mini-batches of Dataset. it satisfies trainer.DataLoader.
+// mini-batches are prepared by background goroutine if Prefetch is positive.
+type Loader struct {
+	Dataset          Dataset
+	BatchSize        int
+	Shuffle          bool
+	KeepPartialBatch bool
+	Prefetch         int
+	Rand             *rand.Rand
+
+	indices []int
+	iter    int
+	batches chan batch
+	done    chan struct{}
+}
+
+type batch struct {
+	x       []mat.Matrix
+	teacher mat.Matrix
+}
+
+// OptionFunc for set option for loader.
+type OptionFunc func(l *Loader)
+
+// Shuffle sets whether samples are shuffled every epoch. default is true.
+func Shuffle(b bool) func(*Loader) {
+	return func(l *Loader) {
+		l.Shuffle = b
+	}
+}
+
+// KeepPartialBatch sets whether the last mini-batch smaller than batch size is used. default is false.
+func KeepPartialBatch(b bool) func(*Loader) {
+	return func(l *Loader) {
+		l.KeepPartialBatch = b
+	}
+}
+
+// Rand sets random number generator of shuffling. default is seeded by current time.
+func Rand(r *rand.Rand) func(*Loader) {
+	return func(l *Loader) {
+		l.Rand = r
+	}
+}
+
+// Prefetch sets number of mini-batches prepared in background. 0 disables prefetching. default is 1.
+func Prefetch(n int) func(*Loader) {
+	return func(l *Loader) {
+		l.Prefetch = n
+	}
+}
+
+// NewLoader inits Loader. it panics if batchSize is not positive.
+func NewLoader(ds Dataset, batchSize int, options ...OptionFunc) *Loader {
+	if batchSize <= 0 {
+		panic("gonnp: batch size should be positive")
+	}
+	l := &Loader{
+		Dataset:   ds,
+		BatchSize: batchSize,
+		// set default value.
+		Shuffle:  true,
+		Prefetch: 1,
+		Rand:     matutil.NewRand(time.Now().UnixNano()),
+	}
+
+	for _, option := range options {
+		option(l)
+	}
+
+	return l
+}
+
+// Len returns number of mini-batches in an epoch.
+func (l *Loader) Len() int {
+	n := l.Dataset.Len() / l.BatchSize
+	if l.KeepPartialBatch && l.Dataset.Len()%l.BatchSize != 0 {
+		n++
+	}
+	return n
+}
+
+// Reset starts new epoch. samples are shuffled if Shuffle is set.
+// mini-batches which are not read in previous epoch are discarded.
+func (l *Loader) Reset() {
+	l.Close()
+
+	size := l.Dataset.Len()
+	if l.Shuffle {
+		l.indices = l.Rand.Perm(size)
+	} else {
+		l.indices = make([]int, size)
+		for i := range l.indices {
+			l.indices[i] = i
+		}
+	}
+	l.iter = 0
+
+	if l.Prefetch <= 0 {
+		return
+	}
+
+	batches := make(chan batch, l.Prefetch)
+	done := make(chan struct{})
+	go func(ds Dataset, indices []int, batchSize, n int) {
+		defer close(batches)
+		for i := 0; i < n; i++ {
+			var b batch
+			b.x, b.teacher = ds.Batch(batchIndices(indices, batchSize, i))
+			select {
+			case batches <- b:
+			case <-done:
+				return
+			}
+		}
+	}(l.Dataset, l.indices, l.BatchSize, l.Len())
+
+	l.batches = batches
+	l.done = done
+}
+
+// Next returns next mini-batch. ok is false at the end of epoch or before Reset is called.
+func (l *Loader) Next() (x []mat.Matrix, teacher mat.Matrix, ok bool) {
+	if l.batches != nil {
+		b, ok := <-l.batches
+		return b.x, b.teacher, ok
+	}
+
+	if l.indices == nil || l.iter >= l.Len() {
+		return nil, nil, false
+	}
+	x, teacher = l.Dataset.Batch(batchIndices(l.indices, l.BatchSize, l.iter))
+	l.iter++
+	return x, teacher, true
+}
+
+// Close stops background goroutine of prefetching and ends current epoch.
+func (l *Loader) Close() {
+	if l.done != nil {
+		close(l.done)
+	}
+	l.indices = nil
+	l.batches = nil
+	l.done = nil
+}
+
+// batchIndices returns indices of i-th mini-batch.
+func batchIndices(indices []int, batchSize, i int) []int {
+	start := i * batchSize
+	end := start + batchSize
+	if end > len(indices) {
+		end = len(indices)
+	}
+	return indices[start:end]
+}
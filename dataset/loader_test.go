@@ -0,0 +1,195 @@
+// +build !e2e
+
+package dataset_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/po3rin/gonnp/dataset"
+	"github.com/po3rin/gonnp/matutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+// seqDataset returns dataset whose i-th sample is i.
+func seqDataset(n int) dataset.Dataset {
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = float64(i)
+	}
+	return dataset.NewMatrix(mat.NewDense(n, 1, d), mat.NewDense(n, 1, d))
+}
+
+// readEpoch reads all mini-batches of an epoch as ids.
+func readEpoch(l *dataset.Loader) [][]float64 {
+	var got [][]float64
+	l.Reset()
+	for {
+		x, _, ok := l.Next()
+		if !ok {
+			break
+		}
+		got = append(got, mat.Col(nil, 0, x[0]))
+	}
+	return got
+}
+
+func TestLoader(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		options []dataset.OptionFunc
+		wantLen int
+		want    [][]float64
+	}{
+		{
+			name:    "drop partial batch",
+			size:    5,
+			options: []dataset.OptionFunc{dataset.Shuffle(false)},
+			wantLen: 2,
+			want:    [][]float64{{0, 1}, {2, 3}},
+		},
+		{
+			name: "keep partial batch",
+			size: 5,
+			options: []dataset.OptionFunc{
+				dataset.Shuffle(false),
+				dataset.KeepPartialBatch(true),
+			},
+			wantLen: 3,
+			want:    [][]float64{{0, 1}, {2, 3}, {4}},
+		},
+		{
+			name: "without prefetch",
+			size: 5,
+			options: []dataset.OptionFunc{
+				dataset.Shuffle(false),
+				dataset.KeepPartialBatch(true),
+				dataset.Prefetch(0),
+			},
+			wantLen: 3,
+			want:    [][]float64{{0, 1}, {2, 3}, {4}},
+		},
+		{
+			name: "large prefetch",
+			size: 4,
+			options: []dataset.OptionFunc{
+				dataset.Shuffle(false),
+				dataset.Prefetch(8),
+			},
+			wantLen: 2,
+			want:    [][]float64{{0, 1}, {2, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			l := dataset.NewLoader(seqDataset(tt.size), 2, tt.options...)
+			defer l.Close()
+
+			if l.Len() != tt.wantLen {
+				t.Errorf("len: want = %v, got = %v", tt.wantLen, l.Len())
+			}
+			// reads 2 epochs to check Reset.
+			for e := 0; e < 2; e++ {
+				got := readEpoch(l)
+				if len(got) != len(tt.want) {
+					t.Fatalf("epoch %v: want = %v, got = %v", e, tt.want, got)
+				}
+				for i := range tt.want {
+					if !floatsEqual(got[i], tt.want[i]) {
+						t.Errorf("epoch %v: want = %v, got = %v", e, tt.want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLoaderShuffle(t *testing.T) {
+	size := 10
+	for _, prefetch := range []int{0, 1} {
+		l := dataset.NewLoader(seqDataset(size), 3, dataset.KeepPartialBatch(true), dataset.Prefetch(prefetch))
+
+		var ids []float64
+		for _, b := range readEpoch(l) {
+			ids = append(ids, b...)
+		}
+		l.Close()
+
+		sort.Float64s(ids)
+		for i := 0; i < size; i++ {
+			if ids[i] != float64(i) {
+				t.Fatalf("prefetch %v: samples are lost or duplicated: %v", prefetch, ids)
+			}
+		}
+	}
+
+	// same seed gives same order with or without prefetching.
+	a := readEpoch(dataset.NewLoader(seqDataset(size), 3, dataset.Prefetch(0), dataset.Rand(matutil.NewRand(1))))
+	l := dataset.NewLoader(seqDataset(size), 3, dataset.Rand(matutil.NewRand(1)))
+	defer l.Close()
+	b := readEpoch(l)
+	for i := range a {
+		if !floatsEqual(a[i], b[i]) {
+			t.Errorf("want = %v, got = %v", a, b)
+		}
+	}
+}
+
+func TestLoaderResetMidEpoch(t *testing.T) {
+	l := dataset.NewLoader(seqDataset(6), 2, dataset.Shuffle(false))
+	defer l.Close()
+
+	if _, _, ok := l.Next(); ok {
+		t.Error("Next before Reset must not return mini-batch")
+	}
+
+	l.Reset()
+	if _, _, ok := l.Next(); !ok {
+		t.Fatal("unexpected end of epoch")
+	}
+
+	// unread mini-batches of previous epoch are discarded.
+	got := readEpoch(l)
+	want := [][]float64{{0, 1}, {2, 3}, {4, 5}}
+	if len(got) != len(want) {
+		t.Fatalf("want = %v, got = %v", want, got)
+	}
+	for i := range want {
+		if !floatsEqual(got[i], want[i]) {
+			t.Errorf("want = %v, got = %v", want, got)
+		}
+	}
+
+	l.Close()
+	if _, _, ok := l.Next(); ok {
+		t.Error("Next after Close must not return mini-batch")
+	}
+}
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNewLoaderPanic(t *testing.T) {
+	for _, batchSize := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("batch size %v: expected panic", batchSize)
+				}
+			}()
+			dataset.NewLoader(seqDataset(3), batchSize)
+		}()
+	}
+}
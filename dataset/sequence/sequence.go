@@ -0,0 +1,135 @@
+// Package sequence provides load functions of sequence to sequence text dataset.
+package sequence
+
+import (
+	"bufio"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/word"
+	"gonum.org/v1/gonum/mat"
+)
+
+// TestRatio is ratio of test data.
+const TestRatio = 0.1
+
+// Data has padded id matrices & char-level vocabulary.
+type Data struct {
+	XTrain *mat.Dense
+	TTrain *mat.Dense
+	XTest  *mat.Dense
+	TTest  *mat.Dense
+	W2ID   word.Word2ID
+	ID2W   word.ID2Word
+}
+
+// LoadData loads question & answer file. each line is separated by tab or first '_'.
+// if separated by '_', it is kept at the head of answer as start symbol.
+// questions & answers are padded with spaces and shuffled with seed before split into train & test data.
+func LoadData(path string, seed int64) (*Data, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "gonnp: failed to open sequence file")
+	}
+	defer file.Close()
+
+	var questions, answers []string
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+		q, a, err := split(line)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+		answers = append(answers, a)
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "gonnp: failed to read sequence file")
+	}
+	if len(questions) == 0 {
+		return nil, errors.New("gonnp: sequence file has no data")
+	}
+
+	questions = pad(questions)
+	answers = pad(answers)
+
+	w2id := make(word.Word2ID)
+	id2w := make(word.ID2Word)
+	for i := range questions {
+		updateVocab(questions[i], w2id, id2w)
+		updateVocab(answers[i], w2id, id2w)
+	}
+
+	x := toIDs(questions, w2id)
+	t := toIDs(answers, w2id)
+
+	// shuffle & split.
+	idx := rand.New(rand.NewSource(seed)).Perm(len(questions))
+	splitAt := len(idx) - int(float64(len(idx))*TestRatio)
+
+	d := &Data{
+		XTrain: matutil.ThinRow(x, idx[:splitAt]),
+		TTrain: matutil.ThinRow(t, idx[:splitAt]),
+		W2ID:   w2id,
+		ID2W:   id2w,
+	}
+	if splitAt < len(idx) {
+		d.XTest = matutil.ThinRow(x, idx[splitAt:])
+		d.TTest = matutil.ThinRow(t, idx[splitAt:])
+	}
+	return d, nil
+}
+
+func split(line string) (q, a string, err error) {
+	if i := strings.Index(line, "\t"); i >= 0 {
+		return line[:i], line[i+1:], nil
+	}
+	if i := strings.Index(line, "_"); i >= 0 {
+		return line[:i], line[i:], nil
+	}
+	return "", "", errors.Errorf("gonnp: line has no separator: %q", line)
+}
+
+// pad pads strings with spaces to max length.
+func pad(lines []string) []string {
+	var max int
+	for _, l := range lines {
+		if n := len([]rune(l)); n > max {
+			max = n
+		}
+	}
+	padded := make([]string, len(lines))
+	for i, l := range lines {
+		padded[i] = l + strings.Repeat(" ", max-len([]rune(l)))
+	}
+	return padded
+}
+
+func updateVocab(text string, w2id word.Word2ID, id2w word.ID2Word) {
+	for _, r := range text {
+		c := string(r)
+		if _, ok := w2id[c]; !ok {
+			id := float64(len(w2id))
+			w2id[c] = id
+			id2w[id] = c
+		}
+	}
+}
+
+func toIDs(lines []string, w2id word.Word2ID) *mat.Dense {
+	T := len([]rune(lines[0]))
+	ids := make([]float64, 0, len(lines)*T)
+	for _, l := range lines {
+		for _, r := range l {
+			ids = append(ids, w2id[string(r)])
+		}
+	}
+	return mat.NewDense(len(lines), T, ids)
+}
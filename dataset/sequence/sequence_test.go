@@ -0,0 +1,125 @@
+// +build !e2e
+
+package sequence_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/po3rin/gonnp/dataset/sequence"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLoadData(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		wantTrainSize int
+		wantTestSize  int
+		wantXLen      int
+		wantTLen      int
+		wantVocabSize int
+	}{
+		{
+			name:          "separated by _",
+			path:          "../../testdata/addition.txt",
+			wantTrainSize: 54,
+			wantTestSize:  6,
+			wantXLen:      7,
+			wantTLen:      5,
+			wantVocabSize: 13,
+		},
+		{
+			name:          "separated by tab",
+			path:          "../../testdata/date.txt",
+			wantTrainSize: 27,
+			wantTestSize:  3,
+			wantXLen:      24,
+			wantTLen:      11,
+			wantVocabSize: 35,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := sequence.LoadData(tt.path, 1984)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			r, c := d.XTrain.Dims()
+			if r != tt.wantTrainSize || c != tt.wantXLen {
+				t.Errorf("XTrain: want = (%v, %v), got = (%v, %v)", tt.wantTrainSize, tt.wantXLen, r, c)
+			}
+			r, c = d.TTrain.Dims()
+			if r != tt.wantTrainSize || c != tt.wantTLen {
+				t.Errorf("TTrain: want = (%v, %v), got = (%v, %v)", tt.wantTrainSize, tt.wantTLen, r, c)
+			}
+			r, c = d.XTest.Dims()
+			if r != tt.wantTestSize || c != tt.wantXLen {
+				t.Errorf("XTest: want = (%v, %v), got = (%v, %v)", tt.wantTestSize, tt.wantXLen, r, c)
+			}
+			r, c = d.TTest.Dims()
+			if r != tt.wantTestSize || c != tt.wantTLen {
+				t.Errorf("TTest: want = (%v, %v), got = (%v, %v)", tt.wantTestSize, tt.wantTLen, r, c)
+			}
+
+			if len(d.W2ID) != tt.wantVocabSize || len(d.ID2W) != tt.wantVocabSize {
+				t.Errorf("vocab size: want = %v, got = %v, %v", tt.wantVocabSize, len(d.W2ID), len(d.ID2W))
+			}
+
+			// answers start with '_'.
+			for i := 0; i < tt.wantTrainSize; i++ {
+				if got := d.ID2W[d.TTrain.At(i, 0)]; got != "_" {
+					t.Fatalf("answer %v starts with %q", i, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadDataDecode(t *testing.T) {
+	d, err := sequence.LoadData("../../testdata/addition.txt", 1984)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sb strings.Builder
+	_, c := d.XTrain.Dims()
+	for j := 0; j < c; j++ {
+		sb.WriteString(d.ID2W[d.XTrain.At(0, j)])
+	}
+	if !strings.Contains(sb.String(), "+") {
+		t.Errorf("unexpected question %q", sb.String())
+	}
+}
+
+func TestLoadDataSeed(t *testing.T) {
+	path := "../../testdata/addition.txt"
+	d1, err := sequence.LoadData(path, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d2, err := sequence.LoadData(path, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d3, err := sequence.LoadData(path, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !mat.Equal(d1.XTrain, d2.XTrain) || !mat.Equal(d1.TTest, d2.TTest) {
+		t.Error("same seed should return same split")
+	}
+	if mat.Equal(d1.XTrain, d3.XTrain) {
+		t.Error("different seed should return different split")
+	}
+}
+
+func TestLoadDataError(t *testing.T) {
+	if _, err := sequence.LoadData("../../testdata/notfound.txt", 1); err == nil {
+		t.Error("expected error")
+	}
+}
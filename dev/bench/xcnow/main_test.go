@@ -7,7 +7,9 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/models"
 	"github.com/po3rin/gonnp/optimizers"
 	"github.com/po3rin/gonnp/trainer"
@@ -40,7 +42,7 @@ func BenchmarkCbow(b *testing.B) {
 
 	contexts, target := word.CreateContextsAndTarget(corpus, windowSize)
 
-	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus)
+	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus, matutil.NewRand(time.Now().UnixNano()))
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
 	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(1000))
 
@@ -66,7 +68,7 @@ func BenchmarkXCbow(b *testing.B) {
 	vocabSize := len(w2id)
 	contexts, target := word.CreateContextsAndTarget(corpus, windowSize)
 
-	model := xmodels.InitCBOW(vocabSize, hiddenSize, windowSize, corpus)
+	model := xmodels.InitCBOW(vocabSize, hiddenSize, windowSize, corpus, matutil.NewRand(time.Now().UnixNano()))
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
 	trainer := xtrainer.InitTrainer(model, optimizer, xtrainer.EvalInterval(1000))
 
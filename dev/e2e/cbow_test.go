@@ -5,11 +5,11 @@ package e2e_test
 import (
 	"io/ioutil"
 	"log"
-	"math/rand"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/models"
 	"github.com/po3rin/gonnp/optimizers"
 	"github.com/po3rin/gonnp/trainer"
@@ -17,8 +17,6 @@ import (
 )
 
 func TestCBOW(t *testing.T) {
-	rand.Seed(time.Now().UnixNano())
-
 	windowSize := 5
 	hiddenSize := 100
 	batchSize := 100
@@ -38,7 +36,7 @@ func TestCBOW(t *testing.T) {
 
 	contexts, target := word.CreateContextsAndTarget(corpus, windowSize)
 
-	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus)
+	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus, matutil.NewRand(time.Now().UnixNano()))
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
 	trainer := trainer.InitTrainer(model, optimizer)
 
@@ -3,28 +3,38 @@
 package e2e_test
 
 import (
-	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/po3rin/gomnist"
+	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/models"
 	"github.com/po3rin/gonnp/optimizers"
 	"github.com/po3rin/gonnp/trainer"
 )
 
 func TestMNIST(t *testing.T) {
-	rand.Seed(time.Now().UnixNano())
-
-	model := models.NewTwoLayerNet(784, 100, 10)
-	optimizer := optimizers.InitSDG(0.01)
-	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(20))
-
 	l := gomnist.NewLoader("./../../testdata", gomnist.OneHotLabel(true), gomnist.Normalization(true))
 	mnist, err := l.Load()
 	if err != nil {
 		t.Fatalf("unexpected error: %v\n", err)
 	}
 
-	trainer.Fit(mnist.TestData, mnist.TestLabels, 10, 100)
+	model := models.NewTwoLayerNet(784, 100, 10, matutil.NewRand(time.Now().UnixNano()))
+	optimizer := optimizers.InitSDG(0.01)
+	trainer := trainer.InitTrainer(
+		model, optimizer,
+		trainer.EvalInterval(20),
+		trainer.ValidationData(mnist.TestData, mnist.TestLabels, trainer.Accuracy(), trainer.TopKAccuracy(3)),
+	)
+
+	trainer.Fit(mnist.TrainData, mnist.TrainLabels, 10, 100)
+
+	acc := trainer.History.Metrics["accuracy"]
+	if len(acc) != 10 {
+		t.Fatalf("want = 10, got = %v", len(acc))
+	}
+	if got := acc[len(acc)-1]; got < 0.8 {
+		t.Errorf("accuracy is too low: %v", got)
+	}
 }
@@ -0,0 +1,42 @@
+// +build e2e
+
+package e2e_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"github.com/po3rin/gonnp/word"
+)
+
+func TestRNNLM(t *testing.T) {
+	batchSize := 10
+	wordVecSize := 100
+	hiddenSize := 100
+	timeSize := 5
+	maxEpoch := 10
+
+	file, err := os.Open("../../testdata/golang.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+	defer file.Close()
+	text, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+	corpus, w2id, _ := word.PreProcess(string(text))
+	vocabSize := len(w2id)
+
+	model := models.InitRNNLM(vocabSize, wordVecSize, hiddenSize, matutil.NewRand(time.Now().UnixNano()))
+	optimizer := optimizers.InitSDG(0.1)
+	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(10))
+
+	trainer.FitSequence(corpus, maxEpoch, batchSize, timeSize)
+}
@@ -0,0 +1,93 @@
+// +build e2e
+
+package e2e_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/po3rin/gonnp/dataset/sequence"
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"gonum.org/v1/gonum/mat"
+)
+
+// TestSeq2seq trains seq2seq model to reverse digits.
+func TestSeq2seq(t *testing.T) {
+	dataSize := 1000
+	seqLen := 4
+	startID := 10.0
+	vocabSize := 11
+	rng := matutil.NewRand(time.Now().UnixNano())
+
+	x := mat.NewDense(dataSize, seqLen, nil)
+	teacher := mat.NewDense(dataSize, seqLen+1, nil)
+	for i := 0; i < dataSize; i++ {
+		teacher.Set(i, 0, startID)
+		for j := 0; j < seqLen; j++ {
+			d := float64(rng.Intn(10))
+			x.Set(i, j, d)
+			teacher.Set(i, seqLen-j, d)
+		}
+	}
+
+	model := models.InitSeq2seq(vocabSize, 16, 64, rng)
+	optimizer := optimizers.InitSDG(1)
+	trainer := trainer.InitTrainer(
+		model, optimizer,
+		trainer.EvalInterval(20),
+		trainer.MaxGradNorm(5),
+	)
+
+	trainer.Fit(x, teacher, 20, 50)
+
+	var correct int
+	for i := 0; i < 100; i++ {
+		q := mat.DenseCopyOf(x.Slice(i, i+1, 0, seqLen))
+		got := model.Generate(q, startID, seqLen)
+		ok := true
+		for j, id := range got {
+			if id != teacher.At(i, j+1) {
+				ok = false
+			}
+		}
+		if ok {
+			correct++
+		}
+	}
+	t.Logf("accuracy: %v%%", correct)
+}
+
+// TestSeq2seqAddition trains seq2seq model using addition fixture.
+func TestSeq2seqAddition(t *testing.T) {
+	d, err := sequence.LoadData("../../testdata/addition.txt", 1984)
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	model := models.InitSeq2seq(len(d.W2ID), 16, 128, matutil.NewRand(time.Now().UnixNano()))
+	optimizer := optimizers.InitSDG(1)
+	trainer := trainer.InitTrainer(
+		model, optimizer,
+		trainer.EvalInterval(1),
+		trainer.MaxGradNorm(5),
+	)
+
+	trainer.Fit(d.XTrain, d.TTrain, 5, 9)
+
+	_, xc := d.XTest.Dims()
+	_, tc := d.TTest.Dims()
+	q := mat.DenseCopyOf(d.XTest.Slice(0, 1, 0, xc))
+	got := model.Generate(q, d.W2ID["_"], tc-1)
+
+	var question, answer string
+	for j := 0; j < xc; j++ {
+		question += d.ID2W[q.At(0, j)]
+	}
+	for _, id := range got {
+		answer += d.ID2W[id]
+	}
+	t.Logf("%v = %v", question, answer)
+}
@@ -4,7 +4,6 @@ package e2e_test
 
 import (
 	"fmt"
-	"math/rand"
 	"testing"
 	"time"
 
@@ -16,8 +15,6 @@ import (
 )
 
 func TestSimpleCBOW(t *testing.T) {
-	rand.Seed(time.Now().UnixNano())
-
 	windowSize := 1
 	hiddenSize := 5
 	batchSize := 3
@@ -32,7 +29,7 @@ func TestSimpleCBOW(t *testing.T) {
 	te := word.ConvertOneHot(target, vocabSize)
 	co := word.ConvertOneHot(contexts, vocabSize)
 
-	model := models.InitSimpleCBOW(vocabSize, hiddenSize)
+	model := models.InitSimpleCBOW(vocabSize, hiddenSize, matutil.NewRand(time.Now().UnixNano()))
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
 	trainer := trainer.InitTrainer(model, optimizer)
 
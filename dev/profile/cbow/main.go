@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/profile"
 	"github.com/po3rin/gonnp/matutil"
@@ -25,9 +26,10 @@ func main() {
 
 	contexts, target := word.CreateContextsAndTarget(corpus, windowSize)
 
-	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus)
+	rng := matutil.NewRand(time.Now().UnixNano())
+	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus, rng)
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
-	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(1))
+	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(1), trainer.Rand(rng))
 
 	trainer.Fit(contexts, target, maxEpoch, batchSize)
 
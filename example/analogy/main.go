@@ -10,9 +10,12 @@ import (
 // anology word.
 func main() {
 	cbow := &store.CBOW{}
-	cbow.Decode("testdata/cbow.gob")
+	err := cbow.Decode("testdata/cbow.gob")
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	_, err := word.Analogy("man", "king", "women", cbow.W2ID, cbow.ID2W, cbow.WordVecs)
+	_, err = word.Analogy("man", "king", "women", cbow.W2ID, cbow.ID2W, cbow.WordVecs)
 	if err != nil {
 		log.Fatal(err)
 	}
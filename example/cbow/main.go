@@ -2,9 +2,10 @@ package main
 
 import (
 	"log"
-	"math/rand"
+	"os"
 	"time"
 
+	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/models"
 	"github.com/po3rin/gonnp/optimizers"
 	"github.com/po3rin/gonnp/store"
@@ -15,8 +16,6 @@ import (
 
 // train Word2Vec.
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
 	windowSize := 5
 	hiddenSize := 100
 	batchSize := 100
@@ -27,14 +26,27 @@ func main() {
 
 	contexts, target := word.CreateContextsAndTarget(corpus, windowSize)
 
-	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus)
+	rng := matutil.NewRand(time.Now().UnixNano())
+	model := models.InitCBOW(vocabSize, hiddenSize, windowSize, corpus, rng)
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
-	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(20))
+	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(20), trainer.Rand(rng))
 
 	trainer.Fit(contexts, target, maxEpoch, batchSize)
 
 	dist := trainer.GetWordDist()
-	err := store.NewCBOWEncoder(w2id, id2w, dist).Encode("cbow.gob")
+	c := store.NewCBOWEncoder(w2id, id2w, dist)
+	err := c.Encode("cbow.gob")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// export word vectors in word2vec text format for other tools.
+	f, err := os.Create("cbow.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	err = c.EncodeWord2Vec(f)
 	if err != nil {
 		log.Fatal(err)
 	}
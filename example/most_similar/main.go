@@ -1,6 +1,7 @@
 package main
 
 import (
+	"log"
 	"os"
 
 	"github.com/po3rin/gonnp/store"
@@ -10,7 +11,10 @@ import (
 // print most similar word using CBOW model.
 func main() {
 	cbow := &store.CBOW{}
-	cbow.Decode("testdata/cbow.gob")
+	err := cbow.Decode("testdata/cbow.gob")
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	word.WriteMostSimilar(os.Stdout, "you", cbow.W2ID, cbow.ID2W, cbow.WordVecs)
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/testdata/ptb"
+	"github.com/po3rin/gonnp/trainer"
+)
+
+// train RNNLM & evaluate perplexity on valid and test data.
+func main() {
+	batchSize := 20
+	wordVecSize := 100
+	hiddenSize := 100
+	timeSize := 35
+	maxEpoch := 4
+
+	corpus, w2id, _ := ptb.LoadData("testdata", "train")
+	corpusVal, _, _ := ptb.LoadData("testdata", "valid")
+	corpusTest, _, _ := ptb.LoadData("testdata", "test")
+	vocabSize := len(w2id)
+
+	validator, err := trainer.PerplexityValidator(corpusVal, 1, timeSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rng := matutil.NewRand(time.Now().UnixNano())
+	model := models.InitRNNLM(vocabSize, wordVecSize, hiddenSize, rng)
+	optimizer := optimizers.InitSDG(20)
+	tr := trainer.InitTrainer(
+		model, optimizer,
+		trainer.EvalInterval(20),
+		trainer.Validation(validator),
+		trainer.Rand(rng),
+	)
+
+	tr.FitSequence(corpus, maxEpoch, batchSize, timeSize)
+
+	ppl, err := trainer.EvalPerplexity(model, corpusTest, 1, timeSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("test perplexity: %.2f\n", ppl)
+}
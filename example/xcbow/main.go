@@ -2,9 +2,9 @@ package main
 
 import (
 	"log"
-	"math/rand"
 	"time"
 
+	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/optimizers"
 	"github.com/po3rin/gonnp/store"
 	"github.com/po3rin/gonnp/testdata/ptb"
@@ -15,8 +15,6 @@ import (
 
 // train Word2Vec.
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
 	windowSize := 5
 	hiddenSize := 100
 	batchSize := 100
@@ -27,9 +25,10 @@ func main() {
 
 	contexts, target := word.CreateContextsAndTarget(corpus, windowSize)
 
-	model := xmodels.InitCBOW(vocabSize, hiddenSize, windowSize, corpus)
+	rng := matutil.NewRand(time.Now().UnixNano())
+	model := xmodels.InitCBOW(vocabSize, hiddenSize, windowSize, corpus, rng)
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
-	trainer := xtrainer.InitTrainer(model, optimizer, xtrainer.EvalInterval(20))
+	trainer := xtrainer.InitTrainer(model, optimizer, xtrainer.EvalInterval(20), xtrainer.Rand(rng))
 
 	trainer.Fit(contexts, target, maxEpoch, batchSize)
 
@@ -0,0 +1,221 @@
+package layers
+
+import (
+	"math"
+
+	"github.com/po3rin/gonnp/matutil"
+	"gonum.org/v1/gonum/mat"
+)
+
+// WeightSum computes weighted sum of hidden states. hs is (N, T, H) & a is (N, T).
+type WeightSum struct {
+	hs []mat.Matrix
+	a  mat.Matrix
+}
+
+// InitWeightSumLayer inits WeightSum layer.
+func InitWeightSumLayer() *WeightSum {
+	return &WeightSum{}
+}
+
+// Forward is WeightSum forward. it returns context vector (N, H).
+func (w *WeightSum) Forward(hs []mat.Matrix, a mat.Matrix) mat.Matrix {
+	N := len(hs)
+	_, H := hs[0].Dims()
+
+	c := mat.NewDense(N, H, nil)
+	for n, h := range hs {
+		c.RowView(n).(*mat.VecDense).MulVec(h.T(), rowVec(a, n))
+	}
+
+	w.hs = hs
+	w.a = a
+	return c
+}
+
+// Backward is WeightSum backward. it returns dhs (N, T, H) & da (N, T).
+func (w *WeightSum) Backward(dc mat.Matrix) (dhs []mat.Matrix, da mat.Matrix) {
+	N := len(w.hs)
+	T, H := w.hs[0].Dims()
+
+	dhs = make([]mat.Matrix, N)
+	dad := mat.NewDense(N, T, nil)
+	for n, h := range w.hs {
+		d := mat.NewDense(T, H, nil)
+		d.Apply(func(i, j int, v float64) float64 {
+			return w.a.At(n, i) * dc.At(n, j)
+		}, d)
+		dhs[n] = d
+
+		dad.RowView(n).(*mat.VecDense).MulVec(h, rowVec(dc, n))
+	}
+
+	return dhs, dad
+}
+
+// AttentionWeight computes attention weights from dot products of hs (N, T, H) & h (N, H).
+type AttentionWeight struct {
+	hs []mat.Matrix
+	h  mat.Matrix
+	a  *mat.Dense
+}
+
+// InitAttentionWeightLayer inits AttentionWeight layer.
+func InitAttentionWeightLayer() *AttentionWeight {
+	return &AttentionWeight{}
+}
+
+// Forward is AttentionWeight forward. it returns attention weights (N, T).
+func (w *AttentionWeight) Forward(hs []mat.Matrix, h mat.Matrix) mat.Matrix {
+	N := len(hs)
+	T, _ := hs[0].Dims()
+
+	a := mat.NewDense(N, T, nil)
+	for n, m := range hs {
+		row := a.RowView(n).(*mat.VecDense)
+		row.MulVec(m, rowVec(h, n))
+
+		// softmax for each row.
+		max := mat.Max(row)
+		var sum float64
+		for t := 0; t < T; t++ {
+			e := math.Exp(row.AtVec(t) - max)
+			row.SetVec(t, e)
+			sum += e
+		}
+		row.ScaleVec(1/sum, row)
+	}
+
+	w.hs = hs
+	w.h = h
+	w.a = a
+	return a
+}
+
+// Backward is AttentionWeight backward. it returns dhs (N, T, H) & dh (N, H).
+func (w *AttentionWeight) Backward(da mat.Matrix) (dhs []mat.Matrix, dh mat.Matrix) {
+	N := len(w.hs)
+	T, H := w.hs[0].Dims()
+
+	// softmax backward. ds = a * (da - sum(a * da))
+	ds := mat.NewDense(N, T, nil)
+	ds.MulElem(w.a, da)
+	sum := matutil.SumRow(ds)
+	ds.Apply(func(i, j int, v float64) float64 {
+		return v - w.a.At(i, j)*sum.AtVec(i)
+	}, ds)
+
+	dhs = make([]mat.Matrix, N)
+	dhd := mat.NewDense(N, H, nil)
+	for n, m := range w.hs {
+		d := mat.NewDense(T, H, nil)
+		d.Apply(func(i, j int, v float64) float64 {
+			return ds.At(n, i) * w.h.At(n, j)
+		}, d)
+		dhs[n] = d
+
+		dhd.RowView(n).(*mat.VecDense).MulVec(m.T(), ds.RowView(n))
+	}
+
+	return dhs, dhd
+}
+
+// Attention is dot-product attention layer.
+type Attention struct {
+	WeightLayer     *AttentionWeight
+	SumLayer        *WeightSum
+	AttentionWeight mat.Matrix
+}
+
+// InitAttentionLayer inits Attention layer.
+func InitAttentionLayer() *Attention {
+	return &Attention{
+		WeightLayer: InitAttentionWeightLayer(),
+		SumLayer:    InitWeightSumLayer(),
+	}
+}
+
+// Forward is Attention forward. hs is (N, T, H) encoder hidden states & h is (N, H).
+// it returns context vector (N, H).
+func (a *Attention) Forward(hs []mat.Matrix, h mat.Matrix) mat.Matrix {
+	w := a.WeightLayer.Forward(hs, h)
+	a.AttentionWeight = w
+	return a.SumLayer.Forward(hs, w)
+}
+
+// Backward is Attention backward. it returns dhs (N, T, H) & dh (N, H).
+func (a *Attention) Backward(dc mat.Matrix) (dhs []mat.Matrix, dh mat.Matrix) {
+	dhs0, da := a.SumLayer.Backward(dc)
+	dhs1, dh := a.WeightLayer.Backward(da)
+
+	dhs = make([]mat.Matrix, len(dhs0))
+	for n := range dhs0 {
+		r, c := dhs0[n].Dims()
+		d := mat.NewDense(r, c, nil)
+		d.Add(dhs0[n], dhs1[n])
+		dhs[n] = d
+	}
+	return dhs, dh
+}
+
+// TimeAttention is Attention layer for time series data.
+type TimeAttention struct {
+	Layers           []*Attention
+	AttentionWeights []mat.Matrix
+}
+
+// InitTimeAttentionLayer inits TimeAttention layer.
+func InitTimeAttentionLayer() *TimeAttention {
+	return &TimeAttention{}
+}
+
+// Forward is TimeAttention forward. hsEnc is (N, T1, H) & hsDec is (N, T2, H).
+// it returns context vectors (N, T2, H). attention weight (N, T1) of each time is kept in AttentionWeights.
+func (t *TimeAttention) Forward(hsEnc, hsDec []mat.Matrix) []mat.Matrix {
+	N := len(hsDec)
+	T, H := hsDec[0].Dims()
+
+	out := matutil.New3D(N, T, H)
+	t.Layers = make([]*Attention, T)
+	t.AttentionWeights = make([]mat.Matrix, T)
+
+	for i := 0; i < T; i++ {
+		l := InitAttentionLayer()
+		c := l.Forward(hsEnc, matutil.At3D(hsDec, i))
+		matutil.Set3D(out, c, i)
+		t.Layers[i] = l
+		t.AttentionWeights[i] = l.AttentionWeight
+	}
+
+	return out
+}
+
+// Backward is TimeAttention backward. it returns dhsEnc (N, T1, H) & dhsDec (N, T2, H).
+func (t *TimeAttention) Backward(dout []mat.Matrix) (dhsEnc, dhsDec []mat.Matrix) {
+	N := len(dout)
+	T, H := dout[0].Dims()
+
+	dhsDec = matutil.New3D(N, T, H)
+	for i, l := range t.Layers {
+		dhs, dh := l.Backward(matutil.At3D(dout, i))
+		matutil.Set3D(dhsDec, dh, i)
+
+		if dhsEnc == nil {
+			dhsEnc = dhs
+			continue
+		}
+		for n := range dhsEnc {
+			d := mat.DenseCopyOf(dhsEnc[n])
+			d.Add(d, dhs[n])
+			dhsEnc[n] = d
+		}
+	}
+
+	return dhsEnc, dhsDec
+}
+
+// rowVec returns copy of i-th row of x.
+func rowVec(x mat.Matrix, i int) *mat.VecDense {
+	_, c := x.Dims()
+	return mat.NewVecDense(c, mat.Row(nil, i, x))
+}
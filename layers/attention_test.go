@@ -0,0 +1,220 @@
+// +build !e2e
+
+package layers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/layers"
+	"gonum.org/v1/gonum/mat"
+)
+
+func attentionHs() []mat.Matrix {
+	return []mat.Matrix{
+		mat.NewDense(3, 2, []float64{
+			1, 0,
+			0, 1,
+			1, 1,
+		}),
+		mat.NewDense(3, 2, []float64{
+			0.5, -1,
+			2, 0,
+			-1, 0.5,
+		}),
+	}
+}
+
+func TestWeightSum(t *testing.T) {
+	tests := []struct {
+		name    string
+		hs      []mat.Matrix
+		a       mat.Matrix
+		dc      mat.Matrix
+		wantC   mat.Matrix
+		wantDhs []mat.Matrix
+		wantDa  mat.Matrix
+	}{
+		{
+			name: "2x3x2",
+			hs:   attentionHs(),
+			a: mat.NewDense(2, 3, []float64{
+				0.2, 0.3, 0.5,
+				0.1, 0.6, 0.3,
+			}),
+			dc: mat.NewDense(2, 2, []float64{
+				1, -1,
+				0.5, 2,
+			}),
+			wantC: mat.NewDense(2, 2, []float64{
+				0.7, 0.8,
+				0.95, 0.05,
+			}),
+			wantDhs: []mat.Matrix{
+				mat.NewDense(3, 2, []float64{
+					0.2, -0.2,
+					0.3, -0.3,
+					0.5, -0.5,
+				}),
+				mat.NewDense(3, 2, []float64{
+					0.05, 0.2,
+					0.3, 1.2,
+					0.15, 0.6,
+				}),
+			},
+			wantDa: mat.NewDense(2, 3, []float64{
+				1, -1, 0,
+				-1.75, 1, 0.5,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			l := layers.InitWeightSumLayer()
+			gotC := l.Forward(tt.hs, tt.a)
+			if !mat.EqualApprox(gotC, tt.wantC, 1e-7) {
+				t.Errorf("c: want = %v, got = %v", tt.wantC, gotC)
+			}
+
+			gotDhs, gotDa := l.Backward(tt.dc)
+			for i, g := range gotDhs {
+				if !mat.EqualApprox(g, tt.wantDhs[i], 1e-7) {
+					t.Errorf("dhs[%v]: want = %v, got = %v", i, tt.wantDhs[i], g)
+				}
+			}
+			if !mat.EqualApprox(gotDa, tt.wantDa, 1e-7) {
+				t.Errorf("da: want = %v, got = %v", tt.wantDa, gotDa)
+			}
+		})
+	}
+}
+
+func TestAttentionWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		hs      []mat.Matrix
+		h       mat.Matrix
+		da      mat.Matrix
+		wantA   mat.Matrix
+		wantDhs []mat.Matrix
+		wantDh  mat.Matrix
+	}{
+		{
+			name: "2x3x2",
+			hs:   attentionHs(),
+			h: mat.NewDense(2, 2, []float64{
+				1, 2,
+				0, 1,
+			}),
+			da: mat.NewDense(2, 3, []float64{
+				1, 0, -1,
+				0.5, 2, 0,
+			}),
+			wantA: mat.NewDense(2, 3, []float64{
+				0.09003057, 0.24472847, 0.66524096,
+				0.12195165, 0.33149896, 0.54654939,
+			}),
+			wantDhs: []mat.Matrix{
+				mat.NewDense(3, 2, []float64{
+					0.14181709, 0.28363419,
+					0.14077036, 0.28154071,
+					-0.28258745, -0.56517490,
+				}),
+				mat.NewDense(3, 2, []float64{
+					0, -0.02731397,
+					0, 0.42300138,
+					0, -0.39568741,
+				}),
+			},
+			wantDh: mat.NewDense(2, 2, []float64{
+				-0.14077036, -0.14181709,
+				1.22803318, -0.17052974,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			l := layers.InitAttentionWeightLayer()
+			gotA := l.Forward(tt.hs, tt.h)
+			if !mat.EqualApprox(gotA, tt.wantA, 1e-7) {
+				t.Errorf("a: want = %v, got = %v", tt.wantA, gotA)
+			}
+
+			gotDhs, gotDh := l.Backward(tt.da)
+			for i, g := range gotDhs {
+				if !mat.EqualApprox(g, tt.wantDhs[i], 1e-7) {
+					t.Errorf("dhs[%v]: want = %v, got = %v", i, tt.wantDhs[i], g)
+				}
+			}
+			if !mat.EqualApprox(gotDh, tt.wantDh, 1e-7) {
+				t.Errorf("dh: want = %v, got = %v", tt.wantDh, gotDh)
+			}
+		})
+	}
+}
+
+func TestTimeAttentionForward(t *testing.T) {
+	tests := []struct {
+		name        string
+		hsEnc       []mat.Matrix
+		hsDec       []mat.Matrix
+		want        []mat.Matrix
+		wantWeights []mat.Matrix
+	}{
+		{
+			name:  "2x3x2 & 2x2x2",
+			hsEnc: attentionHs(),
+			hsDec: []mat.Matrix{
+				mat.NewDense(2, 2, []float64{
+					1, 2,
+					-1, 0,
+				}),
+				mat.NewDense(2, 2, []float64{
+					0, 1,
+					1, 1,
+				}),
+			},
+			want: []mat.Matrix{
+				mat.NewDense(2, 2, []float64{
+					0.75527153, 0.90996943,
+					0.42388312, 0.78805844,
+				}),
+				mat.NewDense(2, 2, []float64{
+					0.17742436, 0.15132304,
+					1.68270743, -0.03525473,
+				}),
+			},
+			wantWeights: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.09003057, 0.24472847, 0.66524096,
+					0.12195165, 0.33149896, 0.54654939,
+				}),
+				mat.NewDense(2, 3, []float64{
+					0.21194156, 0.57611688, 0.21194156,
+					0.07050946, 0.85898108, 0.07050946,
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			l := layers.InitTimeAttentionLayer()
+			got := l.Forward(tt.hsEnc, tt.hsDec)
+			for i, g := range got {
+				if !mat.EqualApprox(g, tt.want[i], 1e-7) {
+					t.Errorf("out[%v]: want = %v, got = %v", i, tt.want[i], g)
+				}
+			}
+			for i, w := range l.AttentionWeights {
+				if !mat.EqualApprox(w, tt.wantWeights[i], 1e-7) {
+					t.Errorf("weights[%v]: want = %v, got = %v", i, tt.wantWeights[i], w)
+				}
+			}
+		})
+	}
+}
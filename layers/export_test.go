@@ -2,7 +2,11 @@
 
 package layers
 
-import "gonum.org/v1/gonum/mat"
+import (
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
 
 var CrossEntropyErr = crossEntropyErr
 var Softmax = softmax
@@ -24,8 +28,10 @@ func (e *EmbeddingDot) SetCacheTargetW(targetW mat.Matrix) {
 }
 
 func UseCustomRandGenerator(f func(max float64) float64) (resetFunc func()) {
-	var tmp func(max float64) float64
-	tmp, randGenerator = randGenerator, f
+	tmp := randGenerator
+	randGenerator = func(_ *rand.Rand, max float64) float64 {
+		return f(max)
+	}
 	return func() {
 		randGenerator = tmp
 	}
@@ -0,0 +1,245 @@
+package layers
+
+import (
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+type gruCache struct {
+	x     mat.Matrix
+	hPrev mat.Matrix
+	z     *mat.Dense
+	r     *mat.Dense
+	hHat  *mat.Dense
+}
+
+// GRU is Gated Recurrent Unit layer.
+// Weights of update gate, reset gate and candidate are concatenated in this order.
+// Weight is (D, 3H), WeightH is (H, 3H) and Bias is (3H).
+type GRU struct {
+	Param params.Param
+	Grad  params.Grad
+	cache gruCache
+}
+
+// InitGRULayer inits GRU layer.
+func InitGRULayer(wx, wh mat.Matrix, b mat.Vector) *GRU {
+	return &GRU{
+		Param: params.Param{
+			Weight:  wx,
+			WeightH: wh,
+			Bias:    b,
+		},
+	}
+}
+
+// Forward is GRU forward.
+func (g *GRU) Forward(x, hPrev mat.Matrix) mat.Matrix {
+	N, H := hPrev.Dims()
+
+	ax := mat.NewDense(N, 3*H, nil)
+	ax.Product(x, g.Param.Weight)
+	ax = matutil.AddMatVec(ax, g.Param.Bias)
+
+	ah := mat.NewDense(N, 2*H, nil)
+	ah.Product(hPrev, sliceCols(g.Param.WeightH, 0, 2*H))
+
+	z := mat.NewDense(N, H, nil)
+	z.Add(ax.Slice(0, N, 0, H), ah.Slice(0, N, 0, H))
+	z.Apply(sigmoidElem, z)
+
+	r := mat.NewDense(N, H, nil)
+	r.Add(ax.Slice(0, N, H, 2*H), ah.Slice(0, N, H, 2*H))
+	r.Apply(sigmoidElem, r)
+
+	rh := mat.NewDense(N, H, nil)
+	rh.MulElem(r, hPrev)
+
+	hHat := mat.NewDense(N, H, nil)
+	hHat.Product(rh, sliceCols(g.Param.WeightH, 2*H, 3*H))
+	hHat.Add(hHat, ax.Slice(0, N, 2*H, 3*H))
+	hHat.Apply(tanhElem, hHat)
+
+	// hNext = (1 - z) * hPrev + z * hHat
+	hNext := mat.NewDense(N, H, nil)
+	hNext.Apply(func(i, j int, v float64) float64 {
+		return (1-v)*hPrev.At(i, j) + v*hHat.At(i, j)
+	}, z)
+
+	g.cache = gruCache{
+		x:     x,
+		hPrev: hPrev,
+		z:     z,
+		r:     r,
+		hHat:  hHat,
+	}
+
+	return hNext
+}
+
+// Backward is GRU backward.
+func (g *GRU) Backward(dhNext mat.Matrix) (dx, dhPrev mat.Matrix) {
+	cc := g.cache
+	N, H := dhNext.Dims()
+	D, _ := g.Param.Weight.Dims()
+
+	whh := sliceCols(g.Param.WeightH, 2*H, 3*H)
+
+	// candidate
+	dth := mat.NewDense(N, H, nil)
+	dth.Apply(func(i, j int, v float64) float64 {
+		return dhNext.At(i, j) * cc.z.At(i, j) * (1 - v*v)
+	}, cc.hHat)
+
+	dhr := mat.NewDense(N, H, nil)
+	dhr.Product(dth, whh.T())
+
+	// update gate & reset gate
+	da := mat.NewDense(N, 3*H, nil)
+	for i := 0; i < N; i++ {
+		for j := 0; j < H; j++ {
+			z := cc.z.At(i, j)
+			r := cc.r.At(i, j)
+			hp := cc.hPrev.At(i, j)
+			dz := dhNext.At(i, j) * (cc.hHat.At(i, j) - hp)
+			dr := dhr.At(i, j) * hp
+
+			da.Set(i, j, dz*z*(1-z))
+			da.Set(i, H+j, dr*r*(1-r))
+			da.Set(i, 2*H+j, dth.At(i, j))
+		}
+	}
+	dzr := da.Slice(0, N, 0, 2*H)
+
+	// dwx
+	dwx := mat.NewDense(D, 3*H, nil)
+	dwx.Product(cc.x.T(), da)
+
+	// dwh
+	rh := mat.NewDense(N, H, nil)
+	rh.MulElem(cc.r, cc.hPrev)
+	dwh := mat.NewDense(H, 3*H, nil)
+	dwh.Slice(0, H, 0, 2*H).(*mat.Dense).Product(cc.hPrev.T(), dzr)
+	dwh.Slice(0, H, 2*H, 3*H).(*mat.Dense).Product(rh.T(), dth)
+
+	// db
+	db := matutil.SumCol(da)
+
+	// dx
+	dxd := mat.NewDense(N, D, nil)
+	dxd.Product(da, g.Param.Weight.T())
+
+	// dhp = dhNext * (1 - z) + dzr @ Wh[:, :2H].T + r * dhr
+	dhp := mat.NewDense(N, H, nil)
+	dhp.Product(dzr, sliceCols(g.Param.WeightH, 0, 2*H).T())
+	dhp.Apply(func(i, j int, v float64) float64 {
+		return v + dhNext.At(i, j)*(1-cc.z.At(i, j)) + cc.r.At(i, j)*dhr.At(i, j)
+	}, dhp)
+
+	g.Grad.Weight = dwx
+	g.Grad.WeightH = dwh
+	g.Grad.Bias = db
+
+	return dxd, dhp
+}
+
+// TimeGRU is Time GRU layer.
+type TimeGRU struct {
+	Param    params.Param
+	Grad     params.Grad
+	Layers   []*GRU
+	H        mat.Matrix
+	Dh       mat.Matrix
+	Stateful bool
+}
+
+// InitTimeGRULayer inits time GRU layer.
+func InitTimeGRULayer(wx, wh mat.Matrix, b mat.Vector, stateful bool) *TimeGRU {
+	return &TimeGRU{
+		Param: params.Param{
+			Weight:  wx,
+			WeightH: wh,
+			Bias:    b,
+		},
+		Stateful: stateful,
+	}
+}
+
+// Forward TimeGRU forward layer.
+func (t *TimeGRU) Forward(xs []mat.Matrix) []mat.Matrix {
+	N := len(xs)
+	T, _ := xs[0].Dims()
+	H, _ := t.Param.WeightH.Dims()
+
+	hs := matutil.New3D(N, T, H)
+
+	if !t.Stateful || t.H == nil {
+		t.H = mat.NewDense(N, H, nil)
+	}
+
+	t.Layers = make([]*GRU, T)
+	for i := 0; i < T; i++ {
+		l := InitGRULayer(t.Param.Weight, t.Param.WeightH, t.Param.Bias)
+		t.H = l.Forward(matutil.At3D(xs, i), t.H)
+		matutil.Set3D(hs, t.H, i)
+		t.Layers[i] = l
+	}
+
+	return hs
+}
+
+// Backward TimeGRU backward layer.
+func (t *TimeGRU) Backward(dhs []mat.Matrix) []mat.Matrix {
+	N := len(dhs)
+	T, H := dhs[0].Dims()
+	D, _ := t.Param.Weight.Dims()
+
+	dxs := matutil.New3D(N, T, D)
+
+	var dh, dx mat.Matrix = mat.NewDense(N, H, nil), nil
+	wGrad := mat.NewDense(D, 3*H, nil)
+	whGrad := mat.NewDense(H, 3*H, nil)
+	bGrad := mat.NewVecDense(3*H, nil)
+
+	for i := T - 1; i >= 0; i-- {
+		l := t.Layers[i]
+		a := matutil.At3D(dhs, i)
+		a.Add(a, dh)
+		dx, dh = l.Backward(a)
+		matutil.Set3D(dxs, dx, i)
+
+		wGrad.Add(wGrad, l.Grad.Weight)
+		whGrad.Add(whGrad, l.Grad.WeightH)
+		bGrad.AddVec(bGrad, l.Grad.Bias)
+	}
+
+	t.Grad.Weight = wGrad
+	t.Grad.WeightH = whGrad
+	t.Grad.Bias = bGrad
+
+	t.Dh = dh
+	return dxs
+}
+
+// SetState sets state h.
+func (t *TimeGRU) SetState(h mat.Matrix) {
+	t.H = h
+}
+
+// ResetState resets state h.
+func (t *TimeGRU) ResetState() {
+	t.H = nil
+}
+
+// sliceCols returns view of columns [i, j) of x.
+func sliceCols(x mat.Matrix, i, j int) mat.Matrix {
+	r, _ := x.Dims()
+	s, ok := x.(interface {
+		Slice(i, k, j, l int) mat.Matrix
+	})
+	if !ok {
+		s = mat.DenseCopyOf(x)
+	}
+	return s.Slice(0, r, i, j)
+}
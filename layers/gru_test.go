@@ -0,0 +1,305 @@
+// +build !e2e
+
+package layers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/layers"
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	gruWx = mat.NewDense(3, 6, []float64{
+		0.12053919, -0.25439960, -0.41124727, -0.71540296, -0.55298237, 0.15338775,
+		-0.26591530, -0.10478351, 0.40109270, 0.32068260, 0.11721883, 0.48964903,
+		-0.62902123, -0.89004035, 0.61885124, 0.27909443, -0.01532250, -0.01474356,
+	})
+	gruWh = mat.NewDense(2, 6, []float64{
+		0.56098399, -0.26273679, -0.23543340, -0.47715339, -0.07285779, 0.66521706,
+		0.41058841, 0.53431804, 0.23103631, 0.30076344, -0.89599392, 0.60379889,
+	})
+	gruB = mat.NewVecDense(6, []float64{
+		-0.60047577, -0.48191116, 0.44448233, -0.20918432, -0.15760186, -1.40829351,
+	})
+)
+
+func TestGRUForward(t *testing.T) {
+	tests := []struct {
+		name      string
+		wx        mat.Matrix
+		wh        mat.Matrix
+		b         mat.Vector
+		x         mat.Matrix
+		hPrev     mat.Matrix
+		wantHNext mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   gruWx,
+			wh:   gruWh,
+			b:    gruB,
+			x: mat.NewDense(2, 3, []float64{
+				-0.04258410, -0.52264107, 0.67008076,
+				0.15692861, 0.51922486, 0.12626558,
+			}),
+			hPrev: mat.NewDense(2, 2, []float64{
+				0.11130020, -0.49152996,
+				-0.30105291, 0.22701470,
+			}),
+			wantHNext: mat.NewDense(2, 2, []float64{
+				0.07413401, -0.58766924,
+				-0.29188528, -0.17612468,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		g := layers.InitGRULayer(tt.wx, tt.wh, tt.b)
+		got := g.Forward(tt.x, tt.hPrev)
+		if !mat.EqualApprox(got, tt.wantHNext, 1e-7) {
+			t.Fatalf("want = %v, got = %v", tt.wantHNext, got)
+		}
+	}
+}
+
+func TestGRUBackward(t *testing.T) {
+	tests := []struct {
+		name   string
+		wx     mat.Matrix
+		wh     mat.Matrix
+		b      mat.Vector
+		x      mat.Matrix
+		hPrev  mat.Matrix
+		dhNext mat.Matrix
+
+		wantDwx    mat.Matrix
+		wantDwh    mat.Matrix
+		wantDb     mat.Vector
+		wantDx     mat.Matrix
+		wantDhPrev mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   gruWx,
+			wh:   gruWh,
+			b:    gruB,
+			x: mat.NewDense(2, 3, []float64{
+				-0.04258410, -0.52264107, 0.67008076,
+				0.15692861, 0.51922486, 0.12626558,
+			}),
+			hPrev: mat.NewDense(2, 2, []float64{
+				0.11130020, -0.49152996,
+				-0.30105291, 0.22701470,
+			}),
+			dhNext: mat.NewDense(2, 2, []float64{
+				0.46999030, 0.58321859,
+				-0.91390675, 0.00171454,
+			}),
+			wantDwx: mat.NewDense(3, 6, []float64{
+				-0.00038010, 0.00181096, -0.00018599, 0.00146760, -0.04439988, -0.00056508,
+				0.00365305, 0.02282878, -0.00061834, 0.00020315, -0.19415116, -0.00721606,
+				-0.00936638, -0.02960811, -0.00014417, 0.00976534, 0.05144888, 0.00940982,
+			}),
+			wantDwh: mat.NewDense(2, 6, []float64{
+				0.00034834, -0.00477997, 0.00035703, -0.00245462, 0.06029001, 0.00093891,
+				0.00498064, 0.02158178, -0.00027239, -0.00311687, -0.05460346, -0.00280493,
+			}),
+			wantDb: mat.NewVecDense(6, []float64{
+				-0.01877666, -0.04453351, -0.00117529, 0.02484729, -0.12558040, 0.01420480,
+			}),
+			wantDx: mat.NewDense(2, 3, []float64{
+				-0.06534444, 0.03331899, 0.04865051,
+				0.12874053, -0.02392795, 0.01071921,
+			}),
+			wantDhPrev: mat.NewDense(2, 2, []float64{
+				0.34485831, 0.39149179,
+				-0.64135108, 0.11885627,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		g := layers.InitGRULayer(tt.wx, tt.wh, tt.b)
+		_ = g.Forward(tt.x, tt.hPrev)
+
+		gotDx, gotDhPrev := g.Backward(tt.dhNext)
+		if !mat.EqualApprox(gotDx, tt.wantDx, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDx, gotDx)
+		}
+		if !mat.EqualApprox(gotDhPrev, tt.wantDhPrev, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDhPrev, gotDhPrev)
+		}
+
+		if !mat.EqualApprox(g.Grad.Weight, tt.wantDwx, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwx, g.Grad.Weight)
+		}
+		if !mat.EqualApprox(g.Grad.WeightH, tt.wantDwh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwh, g.Grad.WeightH)
+		}
+		if !mat.EqualApprox(g.Grad.Bias, tt.wantDb, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDb, g.Grad.Bias)
+		}
+	}
+}
+
+func TestTimeGRUForward(t *testing.T) {
+	tests := []struct {
+		name   string
+		wx     mat.Matrix
+		wh     mat.Matrix
+		b      mat.Vector
+		xs     []mat.Matrix
+		wantH  mat.Matrix
+		wantHs []mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   gruWx,
+			wh:   gruWh,
+			b:    gruB,
+			xs: []mat.Matrix{
+				mat.NewDense(3, 3, []float64{
+					-0.10203189, 0.02665156, -0.36153990,
+					0.52324039, -0.75744500, -0.62003365,
+					-0.02893895, -0.16934031, -0.13853585,
+				}),
+				mat.NewDense(3, 3, []float64{
+					0.10646419, -0.54650864, -0.44879203,
+					0.27420613, -0.42833161, 0.06454911,
+					0.05660925, 0.30559503, 0.17820485,
+				}),
+			},
+			wantH: mat.NewDense(2, 2, []float64{
+				-0.12165164, -0.75204720,
+				-0.09051384, -0.68684407,
+			}),
+			wantHs: []mat.Matrix{
+				mat.NewDense(3, 2, []float64{
+					-0.03718992, -0.41296571,
+					-0.20998161, -0.65208523,
+					-0.12165164, -0.75204720,
+				}),
+				mat.NewDense(3, 2, []float64{
+					-0.12285368, -0.45248583,
+					-0.15054187, -0.60559131,
+					-0.09051384, -0.68684407,
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		g := layers.InitTimeGRULayer(tt.wx, tt.wh, tt.b, true)
+		got := g.Forward(tt.xs)
+		for i, h := range got {
+			if !mat.EqualApprox(h, tt.wantHs[i], 1e-7) {
+				t.Errorf("want = %v, got = %v", tt.wantHs[i], h)
+			}
+		}
+		if !mat.EqualApprox(g.H, tt.wantH, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantH, g.H)
+		}
+	}
+}
+
+func TestTimeGRUBackward(t *testing.T) {
+	tests := []struct {
+		name string
+		wx   mat.Matrix
+		wh   mat.Matrix
+		b    mat.Vector
+		xs   []mat.Matrix
+		dhs  []mat.Matrix
+
+		wantDwx mat.Matrix
+		wantDwh mat.Matrix
+		wantDb  mat.Vector
+		wantDh  mat.Matrix
+		wantDxs []mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   gruWx,
+			wh:   gruWh,
+			b:    gruB,
+			xs: []mat.Matrix{
+				mat.NewDense(3, 3, []float64{
+					-0.10203189, 0.02665156, -0.36153990,
+					0.52324039, -0.75744500, -0.62003365,
+					-0.02893895, -0.16934031, -0.13853585,
+				}),
+				mat.NewDense(3, 3, []float64{
+					0.10646419, -0.54650864, -0.44879203,
+					0.27420613, -0.42833161, 0.06454911,
+					0.05660925, 0.30559503, 0.17820485,
+				}),
+			},
+			dhs: []mat.Matrix{
+				mat.NewDense(3, 2, []float64{
+					-0.36566617, -0.03212192,
+					-0.17110913, -0.64659197,
+					-0.55490789, 0.26097304,
+				}),
+				mat.NewDense(3, 2, []float64{
+					-0.22251336, -0.76335170,
+					-0.14227885, 1.08858555,
+					-0.22557272, -0.38460253,
+				}),
+			},
+			wantDwx: mat.NewDense(3, 6, []float64{
+				0.03017596, 0.00925332, -0.00019564, -0.00994302, -0.13030337, -0.00398629,
+				-0.05263440, -0.02163927, 0.00064425, 0.01768436, 0.30279569, 0.00232767,
+				-0.04529185, -0.07115923, 0.00014231, 0.01018356, 0.30854476, 0.02358237,
+			}),
+			wantDwh: mat.NewDense(2, 6, []float64{
+				0.00610861, 0.00870598, 0.00026744, 0.00781455, 0.03369424, -0.00111951,
+				0.00475510, 0.01274323, 0.00085011, 0.02994970, 0.09656419, -0.00027879,
+			}),
+			wantDb: mat.NewVecDense(6, []float64{
+				0.05318676, 0.06429880, -0.00155009, -0.05485161, -0.96052002, -0.03141180,
+			}),
+			wantDh: mat.NewDense(2, 2, []float64{
+				-0.38154722, 0.02622164,
+				-0.21060680, -0.01646000,
+			}),
+			wantDxs: []mat.Matrix{
+				mat.NewDense(3, 3, []float64{
+					0.13027267, -0.04702095, -0.04080355,
+					0.11658981, -0.06027410, -0.08427470,
+					0.11023506, -0.01118905, 0.03178132,
+				}),
+				mat.NewDense(3, 3, []float64{
+					0.08309278, -0.03844724, -0.05564875,
+					0.09182748, 0.00733700, 0.07115210,
+					0.02424663, -0.01746976, -0.01397790,
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		g := layers.InitTimeGRULayer(tt.wx, tt.wh, tt.b, false)
+		_ = g.Forward(tt.xs)
+
+		got := g.Backward(tt.dhs)
+		for i, dx := range got {
+			if !mat.EqualApprox(dx, tt.wantDxs[i], 1e-7) {
+				t.Errorf("want = %v, got = %v", tt.wantDxs[i], dx)
+			}
+		}
+
+		if !mat.EqualApprox(g.Grad.Weight, tt.wantDwx, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwx, g.Grad.Weight)
+		}
+		if !mat.EqualApprox(g.Grad.WeightH, tt.wantDwh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwh, g.Grad.WeightH)
+		}
+		if !mat.EqualApprox(g.Grad.Bias, tt.wantDb, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDb, g.Grad.Bias)
+		}
+		if !mat.EqualApprox(g.Dh, tt.wantDh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDh, g.Dh)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package layers
+
+import (
+	"math"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+type lstmCache struct {
+	x     mat.Matrix
+	hPrev mat.Matrix
+	cPrev mat.Matrix
+	f     *mat.Dense
+	g     *mat.Dense
+	i     *mat.Dense
+	o     *mat.Dense
+	cNext *mat.Dense
+}
+
+// LSTM is Long Short-Term Memory layer.
+// Weights of four gates (forget, cell, input, output) are concatenated in this order.
+// Weight is (D, 4H), WeightH is (H, 4H) and Bias is (4H).
+type LSTM struct {
+	Param params.Param
+	Grad  params.Grad
+	cache lstmCache
+}
+
+// InitLSTMLayer inits LSTM layer.
+func InitLSTMLayer(wx, wh mat.Matrix, b mat.Vector) *LSTM {
+	return &LSTM{
+		Param: params.Param{
+			Weight:  wx,
+			WeightH: wh,
+			Bias:    b,
+		},
+	}
+}
+
+// Forward is LSTM forward.
+func (l *LSTM) Forward(x, hPrev, cPrev mat.Matrix) (hNext, cNext mat.Matrix) {
+	N, H := hPrev.Dims()
+
+	a := mat.NewDense(N, 4*H, nil)
+	ax := mat.NewDense(N, 4*H, nil)
+	a.Product(hPrev, l.Param.WeightH)
+	ax.Product(x, l.Param.Weight)
+	a.Add(a, ax)
+	a = matutil.AddMatVec(a, l.Param.Bias)
+
+	f := mat.DenseCopyOf(a.Slice(0, N, 0, H))
+	g := mat.DenseCopyOf(a.Slice(0, N, H, 2*H))
+	i := mat.DenseCopyOf(a.Slice(0, N, 2*H, 3*H))
+	o := mat.DenseCopyOf(a.Slice(0, N, 3*H, 4*H))
+
+	f.Apply(sigmoidElem, f)
+	g.Apply(tanhElem, g)
+	i.Apply(sigmoidElem, i)
+	o.Apply(sigmoidElem, o)
+
+	c := mat.NewDense(N, H, nil)
+	gi := mat.NewDense(N, H, nil)
+	c.MulElem(f, cPrev)
+	gi.MulElem(g, i)
+	c.Add(c, gi)
+
+	h := mat.NewDense(N, H, nil)
+	h.Apply(tanhElem, c)
+	h.MulElem(o, h)
+
+	l.cache = lstmCache{
+		x:     x,
+		hPrev: hPrev,
+		cPrev: cPrev,
+		f:     f,
+		g:     g,
+		i:     i,
+		o:     o,
+		cNext: c,
+	}
+
+	return h, c
+}
+
+// Backward is LSTM backward.
+func (l *LSTM) Backward(dhNext, dcNext mat.Matrix) (dx, dhPrev, dcPrev mat.Matrix) {
+	cc := l.cache
+	N, H := dhNext.Dims()
+
+	tanhC := mat.NewDense(N, H, nil)
+	tanhC.Apply(tanhElem, cc.cNext)
+
+	// ds = dcNext + (dhNext * o) * (1 - tanh(cNext)^2)
+	ds := mat.NewDense(N, H, nil)
+	ds.Apply(func(i, j int, v float64) float64 {
+		return dcNext.At(i, j) + dhNext.At(i, j)*cc.o.At(i, j)*(1-v*v)
+	}, tanhC)
+
+	dcp := mat.NewDense(N, H, nil)
+	dcp.MulElem(ds, cc.f)
+
+	da := mat.NewDense(N, 4*H, nil)
+	for i := 0; i < N; i++ {
+		for j := 0; j < H; j++ {
+			s := ds.At(i, j)
+			f := cc.f.At(i, j)
+			g := cc.g.At(i, j)
+			in := cc.i.At(i, j)
+			o := cc.o.At(i, j)
+
+			da.Set(i, j, s*cc.cPrev.At(i, j)*f*(1-f))
+			da.Set(i, H+j, s*in*(1-g*g))
+			da.Set(i, 2*H+j, s*g*in*(1-in))
+			da.Set(i, 3*H+j, dhNext.At(i, j)*tanhC.At(i, j)*o*(1-o))
+		}
+	}
+
+	// dwh
+	dwh := mat.NewDense(H, 4*H, nil)
+	dwh.Product(cc.hPrev.T(), da)
+
+	// dwx
+	D, _ := l.Param.Weight.Dims()
+	dwx := mat.NewDense(D, 4*H, nil)
+	dwx.Product(cc.x.T(), da)
+
+	// db
+	db := matutil.SumCol(da)
+
+	// dx
+	dxd := mat.NewDense(N, D, nil)
+	dxd.Product(da, l.Param.Weight.T())
+
+	// dhp
+	dhp := mat.NewDense(N, H, nil)
+	dhp.Product(da, l.Param.WeightH.T())
+
+	l.Grad.Weight = dwx
+	l.Grad.WeightH = dwh
+	l.Grad.Bias = db
+
+	return dxd, dhp, dcp
+}
+
+// TimeLSTM is Time LSTM layer.
+type TimeLSTM struct {
+	Param    params.Param
+	Grad     params.Grad
+	Layers   []*LSTM
+	H        mat.Matrix
+	C        mat.Matrix
+	Dh       mat.Matrix
+	Stateful bool
+}
+
+// InitTimeLSTMLayer inits time LSTM layer.
+func InitTimeLSTMLayer(wx, wh mat.Matrix, b mat.Vector, stateful bool) *TimeLSTM {
+	return &TimeLSTM{
+		Param: params.Param{
+			Weight:  wx,
+			WeightH: wh,
+			Bias:    b,
+		},
+		Stateful: stateful,
+	}
+}
+
+// Forward TimeLSTM forward layer.
+func (t *TimeLSTM) Forward(xs []mat.Matrix) []mat.Matrix {
+	N := len(xs)
+	T, _ := xs[0].Dims()
+	_, H := t.Param.WeightH.Dims()
+	H /= 4
+
+	hs := matutil.New3D(N, T, H)
+
+	if !t.Stateful || t.H == nil {
+		t.H = mat.NewDense(N, H, nil)
+	}
+	if !t.Stateful || t.C == nil {
+		t.C = mat.NewDense(N, H, nil)
+	}
+
+	t.Layers = make([]*LSTM, T)
+	for i := 0; i < T; i++ {
+		l := InitLSTMLayer(t.Param.Weight, t.Param.WeightH, t.Param.Bias)
+		t.H, t.C = l.Forward(matutil.At3D(xs, i), t.H, t.C)
+		matutil.Set3D(hs, t.H, i)
+		t.Layers[i] = l
+	}
+
+	return hs
+}
+
+// Backward TimeLSTM backward layer.
+func (t *TimeLSTM) Backward(dhs []mat.Matrix) []mat.Matrix {
+	N := len(dhs)
+	T, H := dhs[0].Dims()
+	D, _ := t.Param.Weight.Dims()
+
+	dxs := matutil.New3D(N, T, D)
+
+	var dh, dc, dx mat.Matrix = mat.NewDense(N, H, nil), mat.NewDense(N, H, nil), nil
+	wGrad := mat.NewDense(D, 4*H, nil)
+	whGrad := mat.NewDense(H, 4*H, nil)
+	bGrad := mat.NewVecDense(4*H, nil)
+
+	for i := T - 1; i >= 0; i-- {
+		l := t.Layers[i]
+		a := matutil.At3D(dhs, i)
+		a.Add(a, dh)
+		dx, dh, dc = l.Backward(a, dc)
+		matutil.Set3D(dxs, dx, i)
+
+		wGrad.Add(wGrad, l.Grad.Weight)
+		whGrad.Add(whGrad, l.Grad.WeightH)
+		bGrad.AddVec(bGrad, l.Grad.Bias)
+	}
+
+	t.Grad.Weight = wGrad
+	t.Grad.WeightH = whGrad
+	t.Grad.Bias = bGrad
+
+	t.Dh = dh
+	return dxs
+}
+
+// SetState sets state h. cell state is reset to zero.
+func (t *TimeLSTM) SetState(h mat.Matrix) {
+	t.H = h
+	t.C = nil
+}
+
+// ResetState resets state h & c.
+func (t *TimeLSTM) ResetState() {
+	t.H = nil
+	t.C = nil
+}
+
+func sigmoidElem(i, j int, v float64) float64 {
+	return 1 / (1 + math.Exp(-v))
+}
+
+func tanhElem(i, j int, v float64) float64 {
+	return math.Tanh(v)
+}
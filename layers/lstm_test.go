@@ -0,0 +1,349 @@
+// +build !e2e
+
+package layers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/layers"
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	lstmWx = mat.NewDense(3, 8, []float64{
+		-0.11995703, 0.45609304, 0.46304348, -0.30445781, -0.19719662, 0.32316271, 1.31522968, 0.58284620,
+		0.05332902, -0.91583652, 1.26689239, 0.43636424, 0.37239436, -0.32109896, 0.34147427, 0.23720989,
+		-0.61612220, -0.24684293, -0.14999579, 0.13411279, -0.07946574, 0.65654130, -0.23332411, 0.00321876,
+	})
+	lstmWh = mat.NewDense(2, 8, []float64{
+		0.84792575, -0.41271772, 0.45366252, -0.18979778, -0.21776177, 0.64346038, -0.12743453, 0.78569754,
+		-0.10004905, -0.34429436, -0.21943047, 0.31277747, 0.22688438, -0.05109547, 0.58084756, 0.59481481,
+	})
+	lstmB = mat.NewVecDense(8, []float64{
+		-0.58662576, -0.04115485, -0.58712436, 0.38546836, 0.12794628, 0.50804889, -0.12370882, -0.04393772,
+	})
+)
+
+func TestLSTMForward(t *testing.T) {
+	tests := []struct {
+		name      string
+		wx        mat.Matrix
+		wh        mat.Matrix
+		b         mat.Vector
+		x         mat.Matrix
+		hPrev     mat.Matrix
+		cPrev     mat.Matrix
+		wantHNext mat.Matrix
+		wantCNext mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   lstmWx,
+			wh:   lstmWh,
+			b:    lstmB,
+			x: mat.NewDense(2, 3, []float64{
+				0.34670588, -0.77643991, -0.84460063,
+				0.27013585, 0.61929093, 0.45441138,
+			}),
+			hPrev: mat.NewDense(2, 2, []float64{
+				-0.23278780, -0.41254725,
+				-0.22545010, -0.77985792,
+			}),
+			cPrev: mat.NewDense(2, 2, []float64{
+				0.09538201, -0.03532975,
+				0.38838487, -0.22265246,
+			}),
+			wantHNext: mat.NewDense(2, 2, []float64{
+				-0.17099553, -0.06340883,
+				0.12752811, 0.06613682,
+			}),
+			wantCNext: mat.NewDense(2, 2, []float64{
+				-0.34615397, -0.16503947,
+				0.27328479, 0.16422439,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		l := layers.InitLSTMLayer(tt.wx, tt.wh, tt.b)
+		gotH, gotC := l.Forward(tt.x, tt.hPrev, tt.cPrev)
+		if !mat.EqualApprox(gotH, tt.wantHNext, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantHNext, gotH)
+		}
+		if !mat.EqualApprox(gotC, tt.wantCNext, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantCNext, gotC)
+		}
+	}
+}
+
+func TestLSTMBackward(t *testing.T) {
+	tests := []struct {
+		name   string
+		wx     mat.Matrix
+		wh     mat.Matrix
+		b      mat.Vector
+		x      mat.Matrix
+		hPrev  mat.Matrix
+		cPrev  mat.Matrix
+		dhNext mat.Matrix
+		dcNext mat.Matrix
+
+		wantDwx    mat.Matrix
+		wantDwh    mat.Matrix
+		wantDb     mat.Vector
+		wantDx     mat.Matrix
+		wantDhPrev mat.Matrix
+		wantDcPrev mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   lstmWx,
+			wh:   lstmWh,
+			b:    lstmB,
+			x: mat.NewDense(2, 3, []float64{
+				0.34670588, -0.77643991, -0.84460063,
+				0.27013585, 0.61929093, 0.45441138,
+			}),
+			hPrev: mat.NewDense(2, 2, []float64{
+				-0.23278780, -0.41254725,
+				-0.22545010, -0.77985792,
+			}),
+			cPrev: mat.NewDense(2, 2, []float64{
+				0.09538201, -0.03532975,
+				0.38838487, -0.22265246,
+			}),
+			dhNext: mat.NewDense(2, 2, []float64{
+				0.03199975, -0.34343730,
+				0.90103766, -0.82790322,
+			}),
+			dcNext: mat.NewDense(2, 2, []float64{
+				0.26628385, 0.63917883,
+				0.39171574, 0.16872311,
+			}),
+			wantDwx: mat.NewDense(3, 8, []float64{
+				0.01876233, 0.00128879, 0.11461696, 0.06743658, -0.00412623, -0.01502950, 0.01527392, -0.00415794,
+				0.03273001, 0.00778448, 0.21164420, -0.25524130, 0.08459012, 0.01545751, 0.03919796, -0.03048398,
+				0.02221709, 0.00655690, 0.14635373, -0.25898405, 0.07852205, 0.02007393, 0.02949357, -0.02603328,
+			}),
+			wantDwh: mat.NewDense(2, 8, []float64{
+				-0.01528849, -0.00124948, -0.09381685, -0.04152686, 0.00005786, 0.01074645, -0.01289788, 0.00422441,
+				-0.05031472, -0.00552918, -0.31174808, -0.04121851, -0.02330479, 0.02469895, -0.04566047, 0.01984901,
+			}),
+			wantDb: mat.NewVecDense(8, []float64{
+				0.06760018, 0.00564219, 0.41507249, 0.17570601, 0.00169148, -0.04663277, 0.05729608, -0.01917166,
+			}),
+			wantDx: mat.NewDense(2, 3, []float64{
+				-0.06072725, 0.14836213, 0.01139291,
+				0.24267290, 0.48322065, -0.13731133,
+			}),
+			wantDhPrev: mat.NewDense(2, 2, []float64{
+				-0.02451917, 0.06927622,
+				0.18172538, -0.08946161,
+			}),
+			wantDcPrev: mat.NewDense(2, 2, []float64{
+				0.11916960, 0.39826877,
+				0.21625704, -0.07007191,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		l := layers.InitLSTMLayer(tt.wx, tt.wh, tt.b)
+		_, _ = l.Forward(tt.x, tt.hPrev, tt.cPrev)
+
+		gotDx, gotDhPrev, gotDcPrev := l.Backward(tt.dhNext, tt.dcNext)
+		if !mat.EqualApprox(gotDx, tt.wantDx, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDx, gotDx)
+		}
+		if !mat.EqualApprox(gotDhPrev, tt.wantDhPrev, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDhPrev, gotDhPrev)
+		}
+		if !mat.EqualApprox(gotDcPrev, tt.wantDcPrev, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDcPrev, gotDcPrev)
+		}
+
+		if !mat.EqualApprox(l.Grad.Weight, tt.wantDwx, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwx, l.Grad.Weight)
+		}
+		if !mat.EqualApprox(l.Grad.WeightH, tt.wantDwh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwh, l.Grad.WeightH)
+		}
+		if !mat.EqualApprox(l.Grad.Bias, tt.wantDb, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDb, l.Grad.Bias)
+		}
+	}
+}
+
+func TestTimeLSTMForward(t *testing.T) {
+	tests := []struct {
+		name   string
+		wx     mat.Matrix
+		wh     mat.Matrix
+		b      mat.Vector
+		xs     []mat.Matrix
+		wantH  mat.Matrix
+		wantC  mat.Matrix
+		wantHs []mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   lstmWx,
+			wh:   lstmWh,
+			b:    lstmB,
+			xs: []mat.Matrix{
+				mat.NewDense(3, 3, []float64{
+					-0.83838201, -0.94796624, 0.09271114,
+					0.10155678, -0.08894271, -0.23372667,
+					0.87937159, 0.42590729, -0.12980531,
+				}),
+				mat.NewDense(3, 3, []float64{
+					-0.17735483, 0.04856669, 0.26092340,
+					0.34346717, -0.13747666, -0.02088599,
+					-0.06205504, -0.39856509, -0.07866674,
+				}),
+			},
+			wantH: mat.NewDense(2, 2, []float64{
+				-0.01319204, 0.20473059,
+				-0.23353783, 0.16081671,
+			}),
+			wantC: mat.NewDense(2, 2, []float64{
+				-0.01674957, 0.34708233,
+				-0.57191525, 0.38558580,
+			}),
+			wantHs: []mat.Matrix{
+				mat.NewDense(3, 2, []float64{
+					-0.07553568, 0.04811709,
+					-0.23206179, 0.12537575,
+					-0.01319204, 0.20473059,
+				}),
+				mat.NewDense(3, 2, []float64{
+					-0.11958206, 0.13449010,
+					-0.22871872, 0.17391894,
+					-0.23353783, 0.16081671,
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		l := layers.InitTimeLSTMLayer(tt.wx, tt.wh, tt.b, true)
+		got := l.Forward(tt.xs)
+		for i, g := range got {
+			if !mat.EqualApprox(g, tt.wantHs[i], 1e-7) {
+				t.Errorf("want = %v, got = %v", tt.wantHs[i], g)
+			}
+		}
+		if !mat.EqualApprox(l.H, tt.wantH, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantH, l.H)
+		}
+		if !mat.EqualApprox(l.C, tt.wantC, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantC, l.C)
+		}
+
+		l.ResetState()
+		if l.H != nil || l.C != nil {
+			t.Errorf("state is not reset: h = %v, c = %v", l.H, l.C)
+		}
+	}
+}
+
+func TestTimeLSTMBackward(t *testing.T) {
+	tests := []struct {
+		name string
+		wx   mat.Matrix
+		wh   mat.Matrix
+		b    mat.Vector
+		xs   []mat.Matrix
+		dhs  []mat.Matrix
+
+		wantDwx mat.Matrix
+		wantDwh mat.Matrix
+		wantDb  mat.Vector
+		wantDh  mat.Matrix
+		wantDxs []mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx:   lstmWx,
+			wh:   lstmWh,
+			b:    lstmB,
+			xs: []mat.Matrix{
+				mat.NewDense(3, 3, []float64{
+					-0.83838201, -0.94796624, 0.09271114,
+					0.10155678, -0.08894271, -0.23372667,
+					0.87937159, 0.42590729, -0.12980531,
+				}),
+				mat.NewDense(3, 3, []float64{
+					-0.17735483, 0.04856669, 0.26092340,
+					0.34346717, -0.13747666, -0.02088599,
+					-0.06205504, -0.39856509, -0.07866674,
+				}),
+			},
+			dhs: []mat.Matrix{
+				mat.NewDense(3, 2, []float64{
+					0.21483412, -0.47123059,
+					-0.62187221, 0.15937462,
+					0.43265734, 0.09217743,
+				}),
+				mat.NewDense(3, 2, []float64{
+					-0.30912466, 0.58326814,
+					0.07451329, -0.26693251,
+					-0.18457622, 0.71286473,
+				}),
+			},
+			wantDwx: mat.NewDense(3, 8, []float64{
+				-0.03045510, 0.00376275, 0.16193501, 0.04769313, 0.01036576, 0.00292769, 0.00702855, 0.00209976,
+				-0.01814568, -0.00860827, 0.08013101, -0.00589889, -0.00189792, -0.00197230, -0.00176528, -0.00692254,
+				0.00079522, -0.00309703, -0.02199016, 0.00722078, -0.00351236, 0.00379675, -0.01160492, 0.00181531,
+			}),
+			wantDwh: mat.NewDense(2, 8, []float64{
+				0.00606715, -0.00681357, -0.03659424, -0.04874142, -0.00861123, -0.00661944, -0.00890913, -0.01540948,
+				-0.00303672, 0.00522625, 0.01965801, 0.03723314, 0.00514424, 0.00496748, 0.00585134, 0.01064114,
+			}),
+			wantDb: mat.NewVecDense(8, []float64{
+				-0.01723608, 0.03468464, 0.09079082, 0.36791416, 0.06877677, 0.06191917, 0.08871638, 0.09479312,
+			}),
+			wantDh: mat.NewDense(2, 2, []float64{
+				-0.00392854, -0.02807760,
+				-0.00026238, 0.09523524,
+			}),
+			wantDxs: []mat.Matrix{
+				mat.NewDense(3, 3, []float64{
+					-0.01198144, -0.02514382, -0.00657638,
+					0.05169983, -0.01107547, -0.00849830,
+					0.07944753, 0.23793722, -0.00059968,
+				}),
+				mat.NewDense(3, 3, []float64{
+					-0.00210860, 0.02823407, 0.03935108,
+					-0.02406375, 0.01717276, 0.01000294,
+					0.03329885, 0.05426751, 0.01889450,
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		l := layers.InitTimeLSTMLayer(tt.wx, tt.wh, tt.b, false)
+		_ = l.Forward(tt.xs)
+
+		got := l.Backward(tt.dhs)
+		for i, dx := range got {
+			if !mat.EqualApprox(dx, tt.wantDxs[i], 1e-7) {
+				t.Errorf("want = %v, got = %v", tt.wantDxs[i], dx)
+			}
+		}
+
+		if !mat.EqualApprox(l.Grad.Weight, tt.wantDwx, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwx, l.Grad.Weight)
+		}
+		if !mat.EqualApprox(l.Grad.WeightH, tt.wantDwh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwh, l.Grad.WeightH)
+		}
+		if !mat.EqualApprox(l.Grad.Bias, tt.wantDb, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDb, l.Grad.Bias)
+		}
+		if !mat.EqualApprox(l.Dh, tt.wantDh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDh, l.Dh)
+		}
+	}
+}
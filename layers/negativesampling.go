@@ -79,10 +79,11 @@ type UnigramSampler struct {
 	SampleSize int
 	VocabSize  int
 	WordP      mat.Vector
+	Rand       *rand.Rand
 }
 
-// InitUnigraSampler inits UnigramSampler for Negative-Sampling.
-func InitUnigraSampler(corpus word.Corpus, power float64, sampleSize int) *UnigramSampler {
+// InitUnigraSampler inits UnigramSampler for Negative-Sampling. negative samples are drawn by rng.
+func InitUnigraSampler(corpus word.Corpus, power float64, sampleSize int, rng *rand.Rand) *UnigramSampler {
 
 	counts := make(map[int]float64, len(corpus))
 	for _, id := range corpus {
@@ -108,6 +109,7 @@ func InitUnigraSampler(corpus word.Corpus, power float64, sampleSize int) *Unigr
 		SampleSize: sampleSize,
 		VocabSize:  vocabSize,
 		WordP:      w.ColView(0),
+		Rand:       rng,
 	}
 
 	return s
@@ -126,7 +128,7 @@ func (u *UnigramSampler) GetNegativeSample(target mat.Vector) mat.Matrix {
 		v.SetVec(int(targetIDx), 0)
 		v.ScaleVec(1/mat.Sum(v), v)
 
-		fs, err := weightedChoice(u.VocabSize, u.SampleSize, v.RawVector().Data)
+		fs, err := weightedChoice(u.Rand, u.VocabSize, u.SampleSize, v.RawVector().Data)
 		if err != nil {
 			panic(err)
 		}
@@ -136,15 +138,15 @@ func (u *UnigramSampler) GetNegativeSample(target mat.Vector) mat.Matrix {
 	return negativeSample
 }
 
-var randGenerator = func(max float64) float64 {
-	r := rand.Float64() * max
+var randGenerator = func(rng *rand.Rand, max float64) float64 {
+	r := rng.Float64() * max
 	return r
 }
 
 // weightedChoice choice num wirh weight. Deduplication is default.
 // ref: https://eli.thegreenplace.net/2010/01/22/weighted-random-generation-in-python/
 // TODO: refacts deduplication & error.
-func weightedChoice(v, size int, w []float64) ([]float64, error) {
+func weightedChoice(rng *rand.Rand, v, size int, w []float64) ([]float64, error) {
 	// convert v to slice.
 	vs := make([]int, 0, v)
 	for i := 0; i < v; i++ {
@@ -158,7 +160,7 @@ func weightedChoice(v, size int, w []float64) ([]float64, error) {
 			sum += v
 		}
 
-		r := randGenerator(sum)
+		r := randGenerator(rng, sum)
 
 		for j, v := range vs {
 			r -= w[j]
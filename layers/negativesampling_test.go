@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/po3rin/gonnp/layers"
+	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/word"
 	"gonum.org/v1/gonum/mat"
 )
@@ -122,10 +123,11 @@ func TestGetNegativeSample(t *testing.T) {
 	randGenerator := func(max float64) float64 {
 		return 0.3
 	}
-	layers.UseCustomRandGenerator(randGenerator)
+	reset := layers.UseCustomRandGenerator(randGenerator)
+	defer reset()
 
 	for _, tt := range tests {
-		u := layers.InitUnigraSampler(tt.corpus, tt.power, tt.sampleSize)
+		u := layers.InitUnigraSampler(tt.corpus, tt.power, tt.sampleSize, matutil.NewRand(1))
 		got := u.GetNegativeSample(tt.target)
 
 		if !mat.EqualApprox(got, tt.want, 1e-7) {
@@ -134,6 +136,17 @@ func TestGetNegativeSample(t *testing.T) {
 	}
 }
 
+func TestGetNegativeSampleRand(t *testing.T) {
+	corpus := word.Corpus{0, 1, 2, 3, 4, 5, 6, 7, 1, 2, 3}
+	target := mat.NewVecDense(4, []float64{1, 3, 0, 7})
+
+	want := layers.InitUnigraSampler(corpus, 0.75, 3, matutil.NewRand(1)).GetNegativeSample(target)
+	got := layers.InitUnigraSampler(corpus, 0.75, 3, matutil.NewRand(1)).GetNegativeSample(target)
+	if !mat.Equal(want, got) {
+		t.Errorf("same seed gives different samples:\nwant = %v\ngot = %v", want, got)
+	}
+}
+
 type SamplerMock struct {
 }
 
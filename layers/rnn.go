@@ -137,20 +137,21 @@ func (t *TimeRNN) Forward(xs []mat.Matrix) []mat.Matrix {
 		t.H = mat.NewDense(len(xs), H, nil)
 	}
 
+	t.Layers = make([]*RNN, T)
 	for i := 0; i < T; i++ {
 		l := InitRNNLayer(t.Param.Weight, t.Param.WeightH, t.Param.Bias)
 		t.H = l.Forward(matutil.At3D(xs, i), t.H)
 		matutil.Set3D(hs, t.H, i)
-		t.Layers = append(t.Layers, l)
+		t.Layers[i] = l
 	}
 
 	return hs
 }
 
-// Backward TimeRNN forward layer.
+// Backward TimeRNN backward layer.
 func (t *TimeRNN) Backward(dhs []mat.Matrix) []mat.Matrix {
 	N := len(dhs)
-	T, _ := dhs[0].Dims()
+	T, H := dhs[0].Dims()
 	D, _ := t.Param.Weight.Dims()
 
 	dxs := make([]mat.Matrix, N)
@@ -159,18 +160,17 @@ func (t *TimeRNN) Backward(dhs []mat.Matrix) []mat.Matrix {
 	}
 
 	var (
-		dh, dx mat.Matrix
-		wGrad  *mat.Dense
-		whGrad *mat.Dense
-		bGrad  *mat.VecDense
+		dh     mat.Matrix = mat.NewDense(N, H, nil)
+		dx     mat.Matrix
+		wGrad  = mat.NewDense(D, H, nil)
+		whGrad = mat.NewDense(H, H, nil)
+		bGrad  = mat.NewVecDense(H, nil)
 	)
 
 	for i := len(t.Layers) - 1; i >= 0; i-- {
 		l := t.Layers[i]
 		a := matutil.At3D(dhs, i)
-		if dh != nil {
-			a.Add(a, dh)
-		}
+		a.Add(a, dh)
 		dx, dh = l.Backward(a)
 		matutil.Set3D(dxs, dx, i)
 
@@ -289,3 +289,122 @@ func TestTimeRNNForward(t *testing.T) {
 		}
 	}
 }
+
+func TestTimeRNNBackward(t *testing.T) {
+	tests := []struct {
+		name string
+		wx   mat.Matrix
+		wh   mat.Matrix
+		b    mat.Vector
+		xs   []mat.Matrix
+		dhs  []mat.Matrix
+
+		wantDwx mat.Matrix
+		wantDwh mat.Matrix
+		wantDb  mat.Vector
+		wantDh  mat.Matrix
+		wantDxs []mat.Matrix
+	}{
+		{
+			name: "real float",
+			wx: mat.NewDense(4, 2, []float64{
+				-1.301632, 1.174006,
+				0.5053295, -0.2112245,
+				-0.92537814, -0.0119501,
+				-0.79524386, -0.73746145,
+			}),
+			wh: mat.NewDense(2, 2, []float64{
+				-0.9877705, 0.34192178,
+				-0.53212917, -0.4354699,
+			}),
+			b: mat.NewVecDense(2, []float64{
+				0.5654435, 0.04113087,
+			}),
+			xs: []mat.Matrix{
+				mat.NewDense(2, 4, []float64{
+					-0.23306161, 0.08041102, -0.04789146, 0.07958755,
+					0.03674997, -0.0297237, 0.0662687, 0.07373171,
+				}),
+				mat.NewDense(2, 4, []float64{
+					-0.1492777, 0.01975339, 0.01831131, 0.10769447,
+					0.2746716, -0.05103411, 0.01416942, -0.11400545,
+				}),
+				mat.NewDense(2, 4, []float64{
+					-0.0879465, 0.02060673, -0.01310325, 0.03105886,
+					-0.23306161, 0.08041102, -0.04789146, 0.07958755,
+				}),
+			},
+			dhs: []mat.Matrix{
+				mat.NewDense(2, 2, []float64{
+					-0.36027144, -0.91344142,
+					-0.02799754, -0.54579943,
+				}),
+				mat.NewDense(2, 2, []float64{
+					0.69355663, -0.17750618,
+					0.24486128, -0.01187469,
+				}),
+				mat.NewDense(2, 2, []float64{
+					0.00332284, -0.70553353,
+					-1.07797570, 0.35268761,
+				}),
+			},
+			wantDwx: mat.NewDense(4, 2, []float64{
+				0.23599674, 0.12046806,
+				-0.08704816, -0.02149843,
+				0.05523749, -0.01791772,
+				-0.06980504, -0.09759008,
+			}),
+			wantDwh: mat.NewDense(2, 2, []float64{
+				-0.44451499, -0.12710413,
+				0.04247310, 0.10864272,
+			}),
+			wantDb: mat.NewVecDense(2, []float64{
+				0.00897460, -1.38920897,
+			}),
+			wantDh: mat.NewDense(3, 2, []float64{
+				0.02276942, 0.40569718,
+				-0.39686718, -0.03672631,
+				-0.80460260, -0.21741279,
+			}),
+			wantDxs: []mat.Matrix{
+				mat.NewDense(2, 4, []float64{
+					-0.42931084, 0.01139988, 0.23229384, 0.66132437,
+					-0.50916337, 0.08421100, 0.03078760, 0.36383283,
+				}),
+				mat.NewDense(2, 4, []float64{
+					-0.72969644, 0.21340515, -0.27685036, -0.03011938,
+					-0.31435147, 0.12030980, -0.21749480, -0.18176436,
+				}),
+				mat.NewDense(2, 4, []float64{
+					-1.31248198, 0.42427970, -0.63793628, -0.29469720,
+					1.65238280, -0.55563543, 0.87749629, 0.49877480,
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		r := layers.InitTimeRNNLayer(tt.wx, tt.wh, tt.b, false)
+		_ = r.Forward(tt.xs)
+
+		got := r.Backward(tt.dhs)
+		for i, dx := range got {
+			if !mat.EqualApprox(dx, tt.wantDxs[i], 1e-7) {
+				t.Errorf("want = %v, got = %v", tt.wantDxs[i], dx)
+			}
+		}
+
+		if !mat.EqualApprox(r.Grad.Weight, tt.wantDwx, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwx, r.Grad.Weight)
+		}
+		if !mat.EqualApprox(r.Grad.WeightH, tt.wantDwh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDwh, r.Grad.WeightH)
+		}
+		if !mat.EqualApprox(r.Grad.Bias, tt.wantDb, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDb, r.Grad.Bias)
+		}
+		if !mat.EqualApprox(r.Dh, tt.wantDh, 1e-7) {
+			t.Errorf("want = %v, got = %v", tt.wantDh, r.Dh)
+		}
+	}
+}
@@ -36,6 +36,87 @@ func (s *SoftmaxWithLoss) Backward() mat.Matrix {
 	return dx
 }
 
+// TimeSoftmaxWithLoss is softmax with loss layer for time series data.
+// teacher labels equal to IgnoreLabel (default -1) are excluded from loss, e.g. padding.
+type TimeSoftmaxWithLoss struct {
+	Y           mat.Matrix
+	Teacher     mat.Matrix
+	IgnoreLabel float64
+}
+
+// InitTimeSoftmaxWithLossLayer inits time softmax with loss layer.
+func InitTimeSoftmaxWithLossLayer() *TimeSoftmaxWithLoss {
+	return &TimeSoftmaxWithLoss{
+		IgnoreLabel: -1,
+	}
+}
+
+// Forward for time softmax with loss layer.
+// xs is (N, T, V) scores and teacher is (N, T) word ids. returns mean of cross entropy error.
+func (s *TimeSoftmaxWithLoss) Forward(xs []mat.Matrix, teacher mat.Matrix) float64 {
+	N := len(xs)
+	T, _ := xs[0].Dims()
+
+	s.Y = softmax(matutil.Reshape3DTo2D(xs))
+	s.Teacher = teacher
+
+	var loss float64
+	for n := 0; n < N; n++ {
+		for t := 0; t < T; t++ {
+			id := teacher.At(n, t)
+			if id == s.IgnoreLabel {
+				continue
+			}
+			loss -= math.Log(s.Y.At(n*T+t, int(id)) + 1e-7)
+		}
+	}
+
+	count := s.count()
+	if count == 0 {
+		return 0
+	}
+	return loss / float64(count)
+}
+
+// Backward for time softmax with loss layer. returns gradient of each time step.
+func (s *TimeSoftmaxWithLoss) Backward() []mat.Matrix {
+	_, T := s.Teacher.Dims()
+	r, c := s.Y.Dims()
+
+	count := s.count()
+	dx := mat.NewDense(r, c, nil)
+	if count == 0 {
+		return matutil.Reshape2DTo3D(dx, T)
+	}
+
+	dx.Apply(func(i, j int, v float64) float64 {
+		id := s.Teacher.At(i/T, i%T)
+		if id == s.IgnoreLabel {
+			return 0
+		}
+		if j == int(id) {
+			v--
+		}
+		return v / float64(count)
+	}, s.Y)
+
+	return matutil.Reshape2DTo3D(dx, T)
+}
+
+// count counts labels not ignored.
+func (s *TimeSoftmaxWithLoss) count() int {
+	N, T := s.Teacher.Dims()
+	var count int
+	for n := 0; n < N; n++ {
+		for t := 0; t < T; t++ {
+			if s.Teacher.At(n, t) != s.IgnoreLabel {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func softmax(x mat.Matrix) mat.Matrix {
 	r, c := x.Dims()
 	if r == 1 || c == 1 {
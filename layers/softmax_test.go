@@ -3,6 +3,7 @@
 package layers_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/po3rin/gonnp/layers"
@@ -183,3 +184,124 @@ func TestCrossEntropyErr(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeSoftmaxWithLossForward(t *testing.T) {
+	tests := []struct {
+		name    string
+		xs      []mat.Matrix
+		teacher mat.Matrix
+		want    float64
+	}{
+		{
+			name: "N=1, T=2",
+			xs: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.3, 2.9, 4,
+					1, 2, 3,
+				}),
+			},
+			teacher: mat.NewDense(1, 2, []float64{1, 2}),
+			want:    0.9066599355457472,
+		},
+		{
+			name: "N=2, T=1",
+			xs: []mat.Matrix{
+				mat.NewDense(1, 3, []float64{0.3, 2.9, 4}),
+				mat.NewDense(1, 3, []float64{1, 2, 3}),
+			},
+			teacher: mat.NewDense(2, 1, []float64{1, 2}),
+			want:    0.9066599355457472,
+		},
+		{
+			name: "with ignore label",
+			xs: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.3, 2.9, 4,
+					1, 2, 3,
+				}),
+			},
+			teacher: mat.NewDense(1, 2, []float64{1, -1}),
+			want:    1.405714056968575,
+		},
+		{
+			name: "all ignored",
+			xs: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.3, 2.9, 4,
+					1, 2, 3,
+				}),
+			},
+			teacher: mat.NewDense(1, 2, []float64{-1, -1}),
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			l := layers.InitTimeSoftmaxWithLossLayer()
+			if got := l.Forward(tt.xs, tt.teacher); math.Abs(got-tt.want) > 1e-7 {
+				t.Fatalf("want = %v, got = %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeSoftmaxWithLossBackward(t *testing.T) {
+	tests := []struct {
+		name    string
+		xs      []mat.Matrix
+		teacher mat.Matrix
+		want    []mat.Matrix
+	}{
+		{
+			name: "N=1, T=2",
+			xs: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.3, 2.9, 4,
+					1, 2, 3,
+				}),
+			},
+			teacher: mat.NewDense(1, 2, []float64{1, 2}),
+			want: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.009105636647773765, -0.37740409353246307, 0.3682984568846893,
+					0.04501528658519023, 0.12236423552739882, -0.1673795221125891,
+				}),
+			},
+		},
+		{
+			name: "with ignore label",
+			xs: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.3, 2.9, 4,
+					1, 2, 3,
+				}),
+			},
+			teacher: mat.NewDense(1, 2, []float64{1, -1}),
+			want: []mat.Matrix{
+				mat.NewDense(2, 3, []float64{
+					0.01821127329554753, -0.7548081870649261, 0.7365969137693786,
+					0, 0, 0,
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			l := layers.InitTimeSoftmaxWithLossLayer()
+			_ = l.Forward(tt.xs, tt.teacher)
+			got := l.Backward()
+			if len(got) != len(tt.want) {
+				t.Fatalf("unexpected lendth: want: %v, got: %v", len(tt.want), len(got))
+			}
+			for i, w := range tt.want {
+				if !mat.EqualApprox(got[i], w, 1e-7) {
+					t.Errorf("want = %v, got = %v", w, got[i])
+				}
+			}
+		})
+	}
+}
@@ -119,19 +119,30 @@ func DivMatVec(x mat.Matrix, v mat.Vector) *mat.Dense {
 }
 
 // NewRandMatrixWithSND creates random matrix according to standard normal distribution.
-func NewRandMatrixWithSND(r, c int) *mat.Dense {
+func NewRandMatrixWithSND(r, c int, rng *rand.Rand) *mat.Dense {
 	a := mat.NewDense(r, c, nil)
 	a.Apply(func(i, j int, v float64) float64 {
-		return rand.NormFloat64()*DsiredStdDev + DesiredMean
+		return rng.NormFloat64()*DsiredStdDev + DesiredMean
+	}, a)
+	return a
+}
+
+// NewRandMatrixWithXavier creates random matrix using Xavier initialization.
+// standard deviation is 1/sqrt(r). it suits layers activated by tanh or sigmoid.
+func NewRandMatrixWithXavier(r, c int, rng *rand.Rand) *mat.Dense {
+	std := 1 / math.Sqrt(float64(r))
+	a := mat.NewDense(r, c, nil)
+	a.Apply(func(i, j int, v float64) float64 {
+		return rng.NormFloat64() * std
 	}, a)
 	return a
 }
 
 // NewRandVecWithSND creates random vector according to standard normal distribution.
-func NewRandVecWithSND(r int, _ []float64) *mat.VecDense {
+func NewRandVecWithSND(r int, _ []float64, rng *rand.Rand) *mat.VecDense {
 	a := make([]float64, 0, r)
 	for i := 0; i < r; i++ {
-		a = append(a, rand.NormFloat64()*DsiredStdDev+DesiredMean)
+		a = append(a, rng.NormFloat64()*DsiredStdDev+DesiredMean)
 	}
 	return mat.NewVecDense(r, a)
 }
@@ -353,7 +353,7 @@ func TestNewRandMatrixWithSND(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			got := matutil.NewRandMatrixWithSND(tt.r, tt.c)
+			got := matutil.NewRandMatrixWithSND(tt.r, tt.c, matutil.NewRand(1))
 			if r, c := got.Dims(); r != tt.r || c != tt.c {
 				t.Fatalf("want = [%v, %v], got = [%v, %v]\n", tt.r, tt.c, r, c)
 			}
@@ -379,7 +379,7 @@ func TestNewRandVecWithSND(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			got := matutil.NewRandVecWithSND(tt.r, nil)
+			got := matutil.NewRandVecWithSND(tt.r, nil, matutil.NewRand(1))
 			if r, _ := got.Dims(); r != tt.r {
 				t.Fatalf("want = %v, got = %v\n", tt.r, r)
 			}
@@ -783,3 +783,20 @@ func TestPrint(t *testing.T) {
 	matutil.PrintDims(d)
 	matutil.Print3D([]mat.Matrix{d})
 }
+
+func TestNewRand(t *testing.T) {
+	r := matutil.NewRand(1)
+	want := matutil.NewRandMatrixWithSND(3, 4, r)
+	wantPerm := r.Perm(10)
+
+	r = matutil.NewRand(1)
+	got := matutil.NewRandMatrixWithSND(3, 4, r)
+	gotPerm := r.Perm(10)
+
+	if !mat.Equal(want, got) {
+		t.Errorf("want = %v, got = %v", want, got)
+	}
+	if !reflect.DeepEqual(wantPerm, gotPerm) {
+		t.Errorf("want = %v, got = %v", wantPerm, gotPerm)
+	}
+}
@@ -0,0 +1,42 @@
+package matutil
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// lockedSource is rand.Source which is safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func newLockedSource(seed int64) *lockedSource {
+	return &lockedSource{
+		src: rand.NewSource(seed).(rand.Source64),
+	}
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// NewRand creates random number generator seeded with seed which is safe for concurrent use.
+// it can be shared by model & trainer to make training reproducible.
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(newLockedSource(seed))
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"math/rand"
+
 	"github.com/po3rin/gonnp/layers"
 	"github.com/po3rin/gonnp/params"
 	"github.com/po3rin/gonnp/word"
@@ -12,18 +14,18 @@ type CBOW struct {
 	LossLayer LossLayerWithParams
 }
 
-func InitCBOW(vocabSize, hiddenSize, windowSize int, corpus word.Corpus) *CBOW {
+func InitCBOW(vocabSize, hiddenSize, windowSize int, corpus word.Corpus, rng *rand.Rand) *CBOW {
 	sampleSize := 5
 
-	w1 := weightGenerator(vocabSize, hiddenSize)
-	w2 := weightGenerator(vocabSize, hiddenSize)
+	w1 := weightGenerator(vocabSize, hiddenSize, rng)
+	w2 := weightGenerator(vocabSize, hiddenSize, rng)
 
 	ls := []Layer{}
 	for i := 0; i < windowSize*2; i++ {
 		ls = append(ls, layers.InitEmbeddingLayer(w1))
 	}
 
-	sampler := layers.InitUnigraSampler(corpus, 0.75, sampleSize)
+	sampler := layers.InitUnigraSampler(corpus, 0.75, sampleSize, rng)
 	return &CBOW{
 		Layers:    ls,
 		LossLayer: layers.InitNegativeSamplingLoss(w2, corpus, sampler, sampleSize),
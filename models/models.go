@@ -10,6 +10,7 @@ import (
 var (
 	weightGenerator = matutil.NewRandMatrixWithSND
 	biasGenerator   = matutil.NewRandVecWithSND
+	xavierGenerator = matutil.NewRandMatrixWithXavier
 )
 
 type Layer interface {
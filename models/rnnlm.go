@@ -0,0 +1,95 @@
+package models
+
+import (
+	"math/rand"
+
+	"github.com/po3rin/gonnp/layers"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+// RNNLM is language model using RNN.
+type RNNLM struct {
+	Embed     *layers.TimeEmbedding
+	RNN       *layers.TimeRNN
+	Affine    *layers.TimeAffine
+	LossLayer *layers.TimeSoftmaxWithLoss
+}
+
+// InitRNNLM inits RNN language model.
+func InitRNNLM(vocabSize, wordVecSize, hiddenSize int, rng *rand.Rand) *RNNLM {
+	embedW := weightGenerator(vocabSize, wordVecSize, rng)
+	rnnWx := xavierGenerator(wordVecSize, hiddenSize, rng)
+	rnnWh := xavierGenerator(hiddenSize, hiddenSize, rng)
+	rnnB := mat.NewVecDense(hiddenSize, nil)
+	affineW := xavierGenerator(hiddenSize, vocabSize, rng)
+	affineB := mat.NewVecDense(vocabSize, nil)
+
+	return &RNNLM{
+		Embed:     layers.InitTimeEmbeddingLayer(embedW),
+		RNN:       layers.InitTimeRNNLayer(rnnWx, rnnWh, rnnB, true),
+		Affine:    layers.InitTimeAffineLayer(affineW, affineB),
+		LossLayer: layers.InitTimeSoftmaxWithLossLayer(),
+	}
+}
+
+// Predict returns scores of next words. xs is (N, T) word ids.
+func (r *RNNLM) Predict(xs mat.Matrix) []mat.Matrix {
+	hs := r.Embed.Forward(xs)
+	hs = r.RNN.Forward(hs)
+	return r.Affine.Forward(hs)
+}
+
+// Forward is RNNLM forward. teacher & x[0] are (N, T) word ids.
+func (r *RNNLM) Forward(teacher mat.Matrix, x ...mat.Matrix) float64 {
+	score := r.Predict(x[0])
+	return r.LossLayer.Forward(score, teacher)
+}
+
+// Backward is RNNLM backward.
+func (r *RNNLM) Backward() mat.Matrix {
+	dout := r.LossLayer.Backward()
+	dout = r.Affine.Backward(dout)
+	dout = r.RNN.Backward(dout)
+	return r.Embed.Backward(dout)
+}
+
+// State returns hidden state of RNN.
+func (r *RNNLM) State() mat.Matrix {
+	return r.RNN.H
+}
+
+// SetState sets hidden state of RNN.
+func (r *RNNLM) SetState(h mat.Matrix) {
+	r.RNN.SetState(h)
+}
+
+// ResetState resets hidden state of RNN.
+func (r *RNNLM) ResetState() {
+	r.RNN.ResetState()
+}
+
+// GetParams gets params that layers have.
+func (r *RNNLM) GetParams() []params.Param {
+	return []params.Param{
+		r.Embed.Param,
+		r.RNN.Param,
+		r.Affine.Param,
+	}
+}
+
+// GetGrads gets gradient that layers have.
+func (r *RNNLM) GetGrads() []params.Grad {
+	return []params.Grad{
+		r.Embed.Grad,
+		r.RNN.Grad,
+		r.Affine.Grad,
+	}
+}
+
+// UpdateParams updates lyaers params using RNNLM's params.
+func (r *RNNLM) UpdateParams(ps []params.Param) {
+	r.Embed.Param = ps[0]
+	r.RNN.Param = ps[1]
+	r.Affine.Param = ps[2]
+}
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"math/rand"
+
+	"github.com/po3rin/gonnp/layers"
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Encoder encodes word ids into hidden state.
+type Encoder struct {
+	Embed *layers.TimeEmbedding
+	LSTM  *layers.TimeLSTM
+	hs    []mat.Matrix
+}
+
+// InitEncoder inits encoder.
+func InitEncoder(vocabSize, wordVecSize, hiddenSize int, rng *rand.Rand) *Encoder {
+	embedW := weightGenerator(vocabSize, wordVecSize, rng)
+	lstmWx := xavierGenerator(wordVecSize, 4*hiddenSize, rng)
+	lstmWh := xavierGenerator(hiddenSize, 4*hiddenSize, rng)
+	lstmB := mat.NewVecDense(4*hiddenSize, nil)
+
+	return &Encoder{
+		Embed: layers.InitTimeEmbeddingLayer(embedW),
+		LSTM:  layers.InitTimeLSTMLayer(lstmWx, lstmWh, lstmB, false),
+	}
+}
+
+// Forward is encoder forward. xs is (N, T) word ids. it returns last hidden state (N, H).
+func (e *Encoder) Forward(xs mat.Matrix) mat.Matrix {
+	hs := e.Embed.Forward(xs)
+	hs = e.LSTM.Forward(hs)
+	e.hs = hs
+
+	T, _ := hs[0].Dims()
+	return matutil.At3D(hs, T-1)
+}
+
+// Backward is encoder backward. dh is gradient of last hidden state.
+func (e *Encoder) Backward(dh mat.Matrix) {
+	N := len(e.hs)
+	T, H := e.hs[0].Dims()
+
+	dhs := matutil.New3D(N, T, H)
+	matutil.Set3D(dhs, dh, T-1)
+
+	dout := e.LSTM.Backward(dhs)
+	e.Embed.Backward(dout)
+}
+
+// GetParams gets params that layers have.
+func (e *Encoder) GetParams() []params.Param {
+	return []params.Param{
+		e.Embed.Param,
+		e.LSTM.Param,
+	}
+}
+
+// GetGrads gets gradient that layers have.
+func (e *Encoder) GetGrads() []params.Grad {
+	return []params.Grad{
+		e.Embed.Grad,
+		e.LSTM.Grad,
+	}
+}
+
+// UpdateParams updates lyaers params using encoder's params.
+func (e *Encoder) UpdateParams(ps []params.Param) {
+	e.Embed.Param = ps[0]
+	e.LSTM.Param = ps[1]
+}
+
+// Decoder decodes hidden state into scores of word ids.
+type Decoder struct {
+	Embed  *layers.TimeEmbedding
+	LSTM   *layers.TimeLSTM
+	Affine *layers.TimeAffine
+}
+
+// InitDecoder inits decoder.
+func InitDecoder(vocabSize, wordVecSize, hiddenSize int, rng *rand.Rand) *Decoder {
+	embedW := weightGenerator(vocabSize, wordVecSize, rng)
+	lstmWx := xavierGenerator(wordVecSize, 4*hiddenSize, rng)
+	lstmWh := xavierGenerator(hiddenSize, 4*hiddenSize, rng)
+	lstmB := mat.NewVecDense(4*hiddenSize, nil)
+	affineW := xavierGenerator(hiddenSize, vocabSize, rng)
+	affineB := mat.NewVecDense(vocabSize, nil)
+
+	return &Decoder{
+		Embed:  layers.InitTimeEmbeddingLayer(embedW),
+		LSTM:   layers.InitTimeLSTMLayer(lstmWx, lstmWh, lstmB, true),
+		Affine: layers.InitTimeAffineLayer(affineW, affineB),
+	}
+}
+
+// Forward is decoder forward. xs is (N, T) word ids & h is encoder's hidden state.
+func (d *Decoder) Forward(xs, h mat.Matrix) []mat.Matrix {
+	d.LSTM.SetState(h)
+
+	out := d.Embed.Forward(xs)
+	out = d.LSTM.Forward(out)
+	return d.Affine.Forward(out)
+}
+
+// Backward is decoder backward. it returns gradient of encoder's hidden state.
+func (d *Decoder) Backward(dscore []mat.Matrix) mat.Matrix {
+	dout := d.Affine.Backward(dscore)
+	dout = d.LSTM.Backward(dout)
+	d.Embed.Backward(dout)
+	return d.LSTM.Dh
+}
+
+// Generate generates word ids greedily from hidden state h (1, H).
+func (d *Decoder) Generate(h mat.Matrix, startID float64, length int) []float64 {
+	sampled := make([]float64, 0, length)
+	id := startID
+	d.LSTM.SetState(h)
+
+	for i := 0; i < length; i++ {
+		x := mat.NewDense(1, 1, []float64{id})
+		out := d.Embed.Forward(x)
+		out = d.LSTM.Forward(out)
+		score := d.Affine.Forward(out)
+
+		id = float64(floats.MaxIdx(mat.Row(nil, 0, score[0])))
+		sampled = append(sampled, id)
+	}
+	return sampled
+}
+
+// GetParams gets params that layers have.
+func (d *Decoder) GetParams() []params.Param {
+	return []params.Param{
+		d.Embed.Param,
+		d.LSTM.Param,
+		d.Affine.Param,
+	}
+}
+
+// GetGrads gets gradient that layers have.
+func (d *Decoder) GetGrads() []params.Grad {
+	return []params.Grad{
+		d.Embed.Grad,
+		d.LSTM.Grad,
+		d.Affine.Grad,
+	}
+}
+
+// UpdateParams updates lyaers params using decoder's params.
+func (d *Decoder) UpdateParams(ps []params.Param) {
+	d.Embed.Param = ps[0]
+	d.LSTM.Param = ps[1]
+	d.Affine.Param = ps[2]
+}
+
+// Seq2seq is sequence to sequence model using encoder & decoder.
+type Seq2seq struct {
+	Encoder   *Encoder
+	Decoder   *Decoder
+	LossLayer *layers.TimeSoftmaxWithLoss
+}
+
+// InitSeq2seq inits seq2seq model.
+func InitSeq2seq(vocabSize, wordVecSize, hiddenSize int, rng *rand.Rand) *Seq2seq {
+	return &Seq2seq{
+		Encoder:   InitEncoder(vocabSize, wordVecSize, hiddenSize, rng),
+		Decoder:   InitDecoder(vocabSize, wordVecSize, hiddenSize, rng),
+		LossLayer: layers.InitTimeSoftmaxWithLossLayer(),
+	}
+}
+
+// Forward is seq2seq forward. x[0] is (N, T1) input word ids & teacher is (N, T2) output word ids.
+// teacher[:, :-1] is fed to decoder & teacher[:, 1:] is used as target.
+func (s *Seq2seq) Forward(teacher mat.Matrix, x ...mat.Matrix) float64 {
+	N, T := teacher.Dims()
+	ts := mat.DenseCopyOf(teacher)
+	decoderXs := ts.Slice(0, N, 0, T-1)
+	decoderTs := ts.Slice(0, N, 1, T)
+
+	h := s.Encoder.Forward(x[0])
+	score := s.Decoder.Forward(decoderXs, h)
+	return s.LossLayer.Forward(score, decoderTs)
+}
+
+// Backward is seq2seq backward.
+func (s *Seq2seq) Backward() mat.Matrix {
+	dout := s.LossLayer.Backward()
+	dh := s.Decoder.Backward(dout)
+	s.Encoder.Backward(dh)
+	return nil
+}
+
+// Generate encodes xs (1, T) & generates length word ids greedily from startID.
+func (s *Seq2seq) Generate(xs mat.Matrix, startID float64, length int) []float64 {
+	h := s.Encoder.Forward(xs)
+	return s.Decoder.Generate(h, startID, length)
+}
+
+// GetParams gets params that layers have.
+func (s *Seq2seq) GetParams() []params.Param {
+	return append(s.Encoder.GetParams(), s.Decoder.GetParams()...)
+}
+
+// GetGrads gets gradient that layers have.
+func (s *Seq2seq) GetGrads() []params.Grad {
+	return append(s.Encoder.GetGrads(), s.Decoder.GetGrads()...)
+}
+
+// UpdateParams updates lyaers params using seq2seq's params.
+func (s *Seq2seq) UpdateParams(ps []params.Param) {
+	s.Encoder.UpdateParams(ps[:2])
+	s.Decoder.UpdateParams(ps[2:])
+}
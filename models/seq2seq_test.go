@@ -0,0 +1,177 @@
+// +build !e2e
+
+package models_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSeq2seqForwardBackward(t *testing.T) {
+	tests := []struct {
+		name        string
+		vocabSize   int
+		wordVecSize int
+		hiddenSize  int
+		x           mat.Matrix
+		teacher     mat.Matrix
+	}{
+		{
+			name:        "single sample",
+			vocabSize:   5,
+			wordVecSize: 3,
+			hiddenSize:  4,
+			x:           mat.NewDense(1, 3, []float64{1, 2, 3}),
+			teacher:     mat.NewDense(1, 4, []float64{0, 3, 2, 1}),
+		},
+		{
+			name:        "input & output length differ",
+			vocabSize:   6,
+			wordVecSize: 4,
+			hiddenSize:  3,
+			x: mat.NewDense(2, 4, []float64{
+				1, 2, 3, 4,
+				5, 4, 3, 2,
+			}),
+			teacher: mat.NewDense(2, 3, []float64{
+				0, 4, 1,
+				0, 2, 5,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			model := models.InitSeq2seq(tt.vocabSize, tt.wordVecSize, tt.hiddenSize, matutil.NewRand(1))
+			N, T := tt.teacher.Dims()
+
+			h := model.Encoder.Forward(tt.x)
+			if r, c := h.Dims(); r != N || c != tt.hiddenSize {
+				t.Errorf("encoder state: want = (%v, %v), got = (%v, %v)", N, tt.hiddenSize, r, c)
+			}
+			ts := mat.DenseCopyOf(tt.teacher)
+			score := model.Decoder.Forward(ts.Slice(0, N, 0, T-1), h)
+			if len(score) != N {
+				t.Fatalf("score: want = %v, got = %v", N, len(score))
+			}
+			for _, s := range score {
+				if r, c := s.Dims(); r != T-1 || c != tt.vocabSize {
+					t.Errorf("score: want = (%v, %v), got = (%v, %v)", T-1, tt.vocabSize, r, c)
+				}
+			}
+
+			loss := model.Forward(tt.teacher, tt.x)
+			if math.IsNaN(loss) || loss <= 0 {
+				t.Fatalf("invalid loss %v", loss)
+			}
+			model.Backward()
+
+			ps := model.GetParams()
+			gs := model.GetGrads()
+			if len(ps) != len(gs) {
+				t.Fatalf("want = %v, got = %v", len(ps), len(gs))
+			}
+			for i := range ps {
+				pr, pc := ps[i].Weight.Dims()
+				gr, gc := gs[i].Weight.Dims()
+				if pr != gr || pc != gc {
+					t.Errorf("grad %v: want = (%v, %v), got = (%v, %v)", i, pr, pc, gr, gc)
+				}
+			}
+		})
+	}
+}
+
+func TestSeq2seqEncoderGrad(t *testing.T) {
+	model := models.InitSeq2seq(5, 3, 4, matutil.NewRand(1))
+	x := mat.NewDense(2, 3, []float64{1, 2, 3, 3, 2, 1})
+	teacher := mat.NewDense(2, 4, []float64{
+		0, 3, 2, 1,
+		0, 1, 2, 3,
+	})
+
+	model.Forward(teacher, x)
+	model.Backward()
+
+	if mat.Norm(model.Decoder.LSTM.Dh, 2) == 0 {
+		t.Fatal("gradient of encoder state is zero")
+	}
+
+	// encoder gets gradient only through its last hidden state,
+	// so numerical gradient of encoder weight checks flow from decoder to encoder.
+	w := model.Encoder.LSTM.Param.Weight.(*mat.Dense)
+	got := mat.DenseCopyOf(model.Encoder.LSTM.Grad.Weight)
+	if mat.Norm(got, 2) == 0 {
+		t.Fatal("encoder gradient is zero")
+	}
+
+	eps := 1e-5
+	r, c := w.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := w.At(i, j)
+			w.Set(i, j, v+eps)
+			l1 := model.Forward(teacher, x)
+			w.Set(i, j, v-eps)
+			l2 := model.Forward(teacher, x)
+			w.Set(i, j, v)
+
+			want := (l1 - l2) / (2 * eps)
+			if math.Abs(want-got.At(i, j)) > 1e-6 {
+				t.Errorf("encoder dWx[%v][%v]: want = %v, got = %v", i, j, want, got.At(i, j))
+			}
+		}
+	}
+}
+
+func TestSeq2seqGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		vocabSize  int
+		startID    float64
+		sampleSize int
+	}{
+		{
+			name:       "sample 1 id",
+			vocabSize:  5,
+			startID:    0,
+			sampleSize: 1,
+		},
+		{
+			name:       "sample 4 ids",
+			vocabSize:  6,
+			startID:    5,
+			sampleSize: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			model := models.InitSeq2seq(tt.vocabSize, 3, 4, matutil.NewRand(1))
+			xs := mat.NewDense(1, 3, []float64{1, 2, 3})
+
+			got := model.Generate(xs, tt.startID, tt.sampleSize)
+			if len(got) != tt.sampleSize {
+				t.Fatalf("want = %v, got = %v", tt.sampleSize, len(got))
+			}
+
+			// feeding start id & generated ids to decoder at once gives same argmax at each step.
+			in := append([]float64{tt.startID}, got[:tt.sampleSize-1]...)
+			h := model.Encoder.Forward(xs)
+			score := model.Decoder.Forward(mat.NewDense(1, tt.sampleSize, in), h)
+			for i := 0; i < tt.sampleSize; i++ {
+				want := float64(floats.MaxIdx(mat.Row(nil, i, score[0])))
+				if got[i] != want {
+					t.Errorf("id %v: want = %v, got = %v", i, want, got[i])
+				}
+			}
+		})
+	}
+}
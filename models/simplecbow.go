@@ -1,6 +1,8 @@
 package models
 
 import (
+	"math/rand"
+
 	"github.com/po3rin/gonnp/layers"
 	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/params"
@@ -12,9 +14,9 @@ type SimpleCBOW struct {
 	LossLayer LossLayer
 }
 
-func InitSimpleCBOW(vocabSize, hiddenSize int) *SimpleCBOW {
-	w1 := weightGenerator(vocabSize, hiddenSize)
-	w2 := weightGenerator(hiddenSize, vocabSize)
+func InitSimpleCBOW(vocabSize, hiddenSize int, rng *rand.Rand) *SimpleCBOW {
+	w1 := weightGenerator(vocabSize, hiddenSize, rng)
+	w2 := weightGenerator(hiddenSize, vocabSize, rng)
 
 	ls := []Layer{
 		layers.InitMatMulLayer(w1),
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"math/rand"
+
 	"github.com/po3rin/gonnp/layers"
 	"github.com/po3rin/gonnp/params"
 	"gonum.org/v1/gonum/mat"
@@ -13,12 +15,12 @@ type TwoLayerNet struct {
 }
 
 // NewTwoLayerNet inits 2-layer-network.
-func NewTwoLayerNet(inputSize, hiddenSize, outputSize int) *TwoLayerNet {
-	w1 := weightGenerator(inputSize, hiddenSize)
-	w2 := weightGenerator(hiddenSize, outputSize)
+func NewTwoLayerNet(inputSize, hiddenSize, outputSize int, rng *rand.Rand) *TwoLayerNet {
+	w1 := weightGenerator(inputSize, hiddenSize, rng)
+	w2 := weightGenerator(hiddenSize, outputSize, rng)
 
-	b1 := biasGenerator(hiddenSize, nil)
-	b2 := biasGenerator(outputSize, nil)
+	b1 := biasGenerator(hiddenSize, nil, rng)
+	b2 := biasGenerator(outputSize, nil, rng)
 
 	ls := []Layer{
 		layers.InitAffineLayer(w1, b1),
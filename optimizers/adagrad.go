@@ -0,0 +1,47 @@
+package optimizers
+
+import (
+	"math"
+
+	"github.com/po3rin/gonnp/params"
+)
+
+// AdaGrad has setting for AdaGrad optimizer.
+type AdaGrad struct {
+	LR float64
+	H  []params.Grad
+}
+
+// InitAdaGrad inits AdaGrad optimizer.
+func InitAdaGrad(lr float64) *AdaGrad {
+	return &AdaGrad{
+		LR: lr,
+	}
+}
+
+// Update updates params using AdaGrad argolism.
+// weight, recurrent weight and bias are updated if param has them.
+func (a *AdaGrad) Update(ps []params.Param, grads []params.Grad) []params.Param {
+	a.H = prepareState(a.H, ps)
+
+	f := func(p, g float64, s []float64) float64 {
+		s[0] += g * g
+		return p - a.LR*g/(math.Sqrt(s[0])+1e-7)
+	}
+
+	result := make([]params.Param, len(ps))
+	for i := range ps {
+		result[i] = update(ps[i], grads[i], f, a.H[i])
+	}
+	return result
+}
+
+// GetLR returns learning rate.
+func (a *AdaGrad) GetLR() float64 {
+	return a.LR
+}
+
+// SetLR sets learning rate.
+func (a *AdaGrad) SetLR(lr float64) {
+	a.LR = lr
+}
@@ -0,0 +1,97 @@
+// +build !e2e
+
+package optimizers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestAdaGradUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		lr         float64
+		steps      int
+		params     []params.Param
+		grads      []params.Grad
+		wantParams []params.Param
+	}{
+		{
+			name:  "1 step",
+			lr:    0.1,
+			steps: 1,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.90000001, 1.900000005, 3.09999999, 3.90000002}),
+					WeightH: mat.NewDense(1, 2, []float64{0.900000005, -0.900000005}),
+					Bias:    mat.NewVecDense(2, []float64{0.40000001, -0.5}),
+				},
+			},
+		},
+		{
+			name:  "2 steps",
+			lr:    0.1,
+			steps: 2,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.8292893369, 1.8292893294, 3.1707106631, 3.8292893519}),
+					WeightH: mat.NewDense(1, 2, []float64{0.8292893294, -0.8292893294}),
+					Bias:    mat.NewVecDense(2, []float64{0.3292893369, -0.5}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			optimizer := optimizers.InitAdaGrad(tt.lr)
+			got := tt.params
+			for i := 0; i < tt.steps; i++ {
+				got = optimizer.Update(got, tt.grads)
+			}
+			for i, w := range tt.wantParams {
+				if !mat.EqualApprox(got[i].Weight, w.Weight, 1e-7) {
+					t.Errorf("unexpected weight: want = %v, got = %v", w.Weight, got[i].Weight)
+				}
+				if !mat.EqualApprox(got[i].WeightH, w.WeightH, 1e-7) {
+					t.Errorf("unexpected weightH: want = %v, got = %v", w.WeightH, got[i].WeightH)
+				}
+				if !mat.EqualApprox(got[i].Bias, w.Bias, 1e-7) {
+					t.Errorf("unexpected bias: want = %v, got = %v", w.Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
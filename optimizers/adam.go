@@ -5,16 +5,16 @@ import (
 	"sync"
 
 	"github.com/po3rin/gonnp/params"
-	"gonum.org/v1/gonum/mat"
 )
 
 // Adam has setting for Adam optimizer.
+// M & V keep first & second moment of weight, recurrent weight and bias for each params.
 type Adam struct {
 	LR    float64
 	Beta1 float64
 	Beta2 float64
-	M     []mat.Matrix
-	V     []mat.Matrix
+	M     []params.Grad
+	V     []params.Grad
 	Iter  float64
 }
 
@@ -24,32 +24,27 @@ func InitAdam(lr, beta1, beta2 float64) *Adam {
 		LR:    lr,
 		Beta1: beta1,
 		Beta2: beta2,
-		M:     []mat.Matrix{},
-		V:     []mat.Matrix{},
+		M:     []params.Grad{},
+		V:     []params.Grad{},
 	}
 }
 
-func powElem(i, j int, v float64) float64 {
-	return math.Pow(v, 2)
-}
-
-func sqrtWithMin(i, j int, v float64) float64 {
-	return math.Sqrt(v) + 1e-7
-}
-
-// Update updates params using Adam argolism. supports weight only.
+// Update updates params using Adam argolism.
+// weight, recurrent weight and bias are updated if param has them.
+// bias correction uses Iter which counts calls of Update, so it is shared by all params.
 func (a *Adam) Update(ps []params.Param, grads []params.Grad) []params.Param {
-	if len(a.M) == 0 {
-		for _, p := range ps {
-			r, c := p.Weight.Dims()
-			a.M = append(a.M, mat.NewDense(r, c, nil))
-			a.V = append(a.V, mat.NewDense(r, c, nil))
-		}
-	}
+	a.M = prepareState(a.M, ps)
+	a.V = prepareState(a.V, ps)
 
 	a.Iter++
 	lrT := a.LR * math.Sqrt(1.0-math.Pow(a.Beta2, a.Iter)) / (1.0 - math.Pow(a.Beta1, a.Iter))
 
+	f := func(p, g float64, s []float64) float64 {
+		s[0] += (1 - a.Beta1) * (g - s[0])
+		s[1] += (1 - a.Beta2) * (g*g - s[1])
+		return p - lrT*s[0]/(math.Sqrt(s[1])+1e-7)
+	}
+
 	var wg sync.WaitGroup
 	result := make([]params.Param, len(ps))
 
@@ -57,33 +52,7 @@ func (a *Adam) Update(ps []params.Param, grads []params.Grad) []params.Param {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-
-			// m
-			r, c := grads[i].Weight.Dims()
-			mb := mat.NewDense(r, c, nil)
-			mb.Sub(grads[i].Weight, a.M[i])
-			mb.Scale(1-a.Beta1, mb)
-			mb.Add(a.M[i], mb)
-
-			// v
-			r, c = grads[i].Weight.Dims()
-			vb := mat.NewDense(r, c, nil)
-			vb.Apply(powElem, grads[i].Weight)
-			vb.Sub(vb, a.V[i])
-			vb.Scale(1-a.Beta2, vb)
-			vb.Add(a.V[i], vb)
-
-			// set m & v
-			a.M[i] = mb
-			a.V[i] = vb
-
-			// set new params
-			d := mat.NewDense(r, c, nil)
-			d.Apply(sqrtWithMin, a.V[i])
-			d.DivElem(a.M[i], d)
-			d.Scale(lrT, d)
-			d.Sub(ps[i].Weight, d)
-			result[i].Weight = d
+			result[i] = update(ps[i], grads[i], f, a.M[i], a.V[i])
 		}(i)
 	}
 
@@ -91,3 +60,13 @@ func (a *Adam) Update(ps []params.Param, grads []params.Grad) []params.Param {
 
 	return result
 }
+
+// GetLR returns learning rate.
+func (a *Adam) GetLR() float64 {
+	return a.LR
+}
+
+// SetLR sets learning rate.
+func (a *Adam) SetLR(lr float64) {
+	a.LR = lr
+}
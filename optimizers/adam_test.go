@@ -18,8 +18,8 @@ func TestAdamUpdate(t *testing.T) {
 		beta1      float64
 		beta2      float64
 		Iter       float64
-		m          []mat.Matrix
-		v          []mat.Matrix
+		m          []params.Grad
+		v          []params.Grad
 		params     []params.Param
 		grads      []params.Grad
 		wantParams []params.Param
@@ -30,41 +30,49 @@ func TestAdamUpdate(t *testing.T) {
 			beta1: 0.9,
 			beta2: 0.999,
 			Iter:  1999,
-			m: []mat.Matrix{
-				mat.NewDense(7, 5, []float64{
-					0.00128465, -0.00848898, -0.01045923, -0.00108253, 0.00142057,
-					-0.00369151, 0.00326042, -0.00270434, 0.00397773, -0.0036535,
-					0.00346632, 0.00978515, 0.01233115, -0.00346579, 0.00338299,
-					-0.00188448, -0.02162165, 0.00246958, 0.00201432, -0.00184111,
-					0.00372328, 0.00812329, 0.01028116, -0.00368306, 0.00366662,
-					0.00154161, -0.01015084, -0.01250921, -0.00129981, 0.0017042,
-					-0.00180703, 0.02488208, -0.00517392, 0.0019634, -0.00181239,
-				}),
-				mat.NewDense(5, 7, []float64{
-					-0.00055318, 0.0047359, -0.00222076, 0.00697386, -0.00245548, -0.00593052, -0.00054983,
-					0.00048814, -0.00834097, -0.01418194, 0.01035775, -0.01452606, 0.02572849, 0.00047458,
-					0.00119593, -0.01254619, 0.00263549, 0.01646944, 0.00245858, -0.011413, 0.00119975,
-					0.00057293, -0.00444617, 0.00236244, -0.00732289, 0.00260133, 0.00566265, 0.00056972,
-					-0.00042667, 0.00492711, -0.0022839, 0.0066257, -0.00252432, -0.00589452, -0.00042341,
-				}),
+			m: []params.Grad{
+				params.Grad{
+					Weight: mat.NewDense(7, 5, []float64{
+						0.00128465, -0.00848898, -0.01045923, -0.00108253, 0.00142057,
+						-0.00369151, 0.00326042, -0.00270434, 0.00397773, -0.0036535,
+						0.00346632, 0.00978515, 0.01233115, -0.00346579, 0.00338299,
+						-0.00188448, -0.02162165, 0.00246958, 0.00201432, -0.00184111,
+						0.00372328, 0.00812329, 0.01028116, -0.00368306, 0.00366662,
+						0.00154161, -0.01015084, -0.01250921, -0.00129981, 0.0017042,
+						-0.00180703, 0.02488208, -0.00517392, 0.0019634, -0.00181239,
+					}),
+				},
+				params.Grad{
+					Weight: mat.NewDense(5, 7, []float64{
+						-0.00055318, 0.0047359, -0.00222076, 0.00697386, -0.00245548, -0.00593052, -0.00054983,
+						0.00048814, -0.00834097, -0.01418194, 0.01035775, -0.01452606, 0.02572849, 0.00047458,
+						0.00119593, -0.01254619, 0.00263549, 0.01646944, 0.00245858, -0.011413, 0.00119975,
+						0.00057293, -0.00444617, 0.00236244, -0.00732289, 0.00260133, 0.00566265, 0.00056972,
+						-0.00042667, 0.00492711, -0.0022839, 0.0066257, -0.00252432, -0.00589452, -0.00042341,
+					}),
+				},
 			},
-			v: []mat.Matrix{
-				mat.NewDense(7, 5, []float64{
-					1.9614918e-04, 8.0861995e-04, 9.0201828e-04, 1.4872888e-04, 2.2831510e-04,
-					2.6381426e-04, 2.7802214e-03, 3.3331013e-04, 3.2635487e-04, 2.3475963e-04,
-					4.0934762e-04, 1.6718069e-03, 2.6539846e-03, 3.5236738e-04, 4.4303949e-04,
-					1.1351515e-04, 2.1540979e-03, 8.1511913e-05, 1.3020988e-04, 9.5652125e-05,
-					4.1726153e-04, 1.6212706e-03, 2.5727598e-03, 3.5902637e-04, 4.5169698e-04,
-					1.9728570e-04, 8.1770046e-04, 9.1371808e-04, 1.4953168e-04, 2.2967842e-04,
-					6.7964662e-05, 3.1058039e-03, 2.0302266e-04, 9.2710528e-05, 6.5178618e-05,
-				}),
-				mat.NewDense(5, 7, []float64{
-					8.29980272e-05, 2.99606565e-03, 1.25837438e-02, 2.96134385e-03, 1.26792975e-02, 2.35447031e-03, 8.20337082e-05,
-					4.93213920e-05, 8.22601025e-04, 1.13085341e-02, 3.29224218e-04, 1.12228161e-02, 2.11184472e-03, 4.89494923e-05,
-					8.77638959e-05, 8.24994349e-04, 1.00305285e-02, 1.08394516e-03, 1.00922249e-02, 2.69475277e-03, 8.85798363e-05,
-					7.76581219e-05, 2.76215980e-03, 1.28638428e-02, 2.89932708e-03, 1.29585629e-02, 2.33496819e-03, 7.69242106e-05,
-					8.01798597e-05, 2.90550571e-03, 1.30312685e-02, 2.81218160e-03, 1.31320059e-02, 2.37426115e-03, 7.92823048e-05,
-				}),
+			v: []params.Grad{
+				params.Grad{
+					Weight: mat.NewDense(7, 5, []float64{
+						1.9614918e-04, 8.0861995e-04, 9.0201828e-04, 1.4872888e-04, 2.2831510e-04,
+						2.6381426e-04, 2.7802214e-03, 3.3331013e-04, 3.2635487e-04, 2.3475963e-04,
+						4.0934762e-04, 1.6718069e-03, 2.6539846e-03, 3.5236738e-04, 4.4303949e-04,
+						1.1351515e-04, 2.1540979e-03, 8.1511913e-05, 1.3020988e-04, 9.5652125e-05,
+						4.1726153e-04, 1.6212706e-03, 2.5727598e-03, 3.5902637e-04, 4.5169698e-04,
+						1.9728570e-04, 8.1770046e-04, 9.1371808e-04, 1.4953168e-04, 2.2967842e-04,
+						6.7964662e-05, 3.1058039e-03, 2.0302266e-04, 9.2710528e-05, 6.5178618e-05,
+					}),
+				},
+				params.Grad{
+					Weight: mat.NewDense(5, 7, []float64{
+						8.29980272e-05, 2.99606565e-03, 1.25837438e-02, 2.96134385e-03, 1.26792975e-02, 2.35447031e-03, 8.20337082e-05,
+						4.93213920e-05, 8.22601025e-04, 1.13085341e-02, 3.29224218e-04, 1.12228161e-02, 2.11184472e-03, 4.89494923e-05,
+						8.77638959e-05, 8.24994349e-04, 1.00305285e-02, 1.08394516e-03, 1.00922249e-02, 2.69475277e-03, 8.85798363e-05,
+						7.76581219e-05, 2.76215980e-03, 1.28638428e-02, 2.89932708e-03, 1.29585629e-02, 2.33496819e-03, 7.69242106e-05,
+						8.01798597e-05, 2.90550571e-03, 1.30312685e-02, 2.81218160e-03, 1.31320059e-02, 2.37426115e-03, 7.92823048e-05,
+					}),
+				},
 			},
 			params: []params.Param{
 				params.Param{
@@ -133,6 +141,33 @@ func TestAdamUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "weight, recurrent weight and bias from zero state",
+			lr:    0.01,
+			beta1: 0.9,
+			beta2: 0.999,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.9900000316, 1.9900000158, 3.0099999684, 3.9900000632}),
+					WeightH: mat.NewDense(1, 2, []float64{0.9900000158, -0.9900000158}),
+					Bias:    mat.NewVecDense(2, []float64{0.4900000316, -0.5}),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -153,6 +188,12 @@ func TestAdamUpdate(t *testing.T) {
 				if !mat.EqualApprox(got[i].Weight, w.Weight, 1e-8) {
 					t.Errorf("x:\nwant = %d\ngot = %d", w.Weight, got[i].Weight)
 				}
+				if w.WeightH != nil && !mat.EqualApprox(got[i].WeightH, w.WeightH, 1e-8) {
+					t.Errorf("wh:\nwant = %d\ngot = %d", w.WeightH, got[i].WeightH)
+				}
+				if w.Bias != nil && !mat.EqualApprox(got[i].Bias, w.Bias, 1e-8) {
+					t.Errorf("b:\nwant = %d\ngot = %d", w.Bias, got[i].Bias)
+				}
 			}
 		})
 	}
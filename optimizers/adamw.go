@@ -0,0 +1,28 @@
+package optimizers
+
+import (
+	"github.com/po3rin/gonnp/params"
+)
+
+// AdamW has setting for Adam optimizer with decoupled weight decay.
+type AdamW struct {
+	*Adam
+	Decay DecayRule
+}
+
+// InitAdamW inits AdamW optimizer. decay is applied to params directly, not to gradient.
+func InitAdamW(lr, beta1, beta2 float64, decay DecayRule) *AdamW {
+	return &AdamW{
+		Adam:  InitAdam(lr, beta1, beta2),
+		Decay: decay,
+	}
+}
+
+// Update updates params using AdamW argolism.
+// params are shrunk by LR * decay rate before Adam update.
+func (a *AdamW) Update(ps []params.Param, grads []params.Grad) []params.Param {
+	if a.Decay != nil {
+		ps = decay(ps, a.Decay, a.LR)
+	}
+	return a.Adam.Update(ps, grads)
+}
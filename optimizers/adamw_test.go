@@ -0,0 +1,106 @@
+// +build !e2e
+
+package optimizers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestAdamWUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		lr         float64
+		beta1      float64
+		beta2      float64
+		decay      optimizers.DecayRule
+		steps      int
+		params     []params.Param
+		grads      []params.Grad
+		wantParams []params.Param
+	}{
+		{
+			name:  "1 step",
+			lr:    0.01,
+			beta1: 0.9,
+			beta2: 0.999,
+			decay: optimizers.WeightDecay(0.5),
+			steps: 1,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.9850000316, 1.9800000158, 2.9949999684, 3.9700000632}),
+					WeightH: mat.NewDense(1, 2, []float64{0.9850000158, -0.9850000158}),
+					Bias:    mat.NewVecDense(2, []float64{0.4900000316, -0.5}),
+				},
+			},
+		},
+		{
+			name:  "2 steps",
+			lr:    0.01,
+			beta1: 0.9,
+			beta2: 0.999,
+			decay: optimizers.WeightDecay(0.5),
+			steps: 2,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.9700750538, 1.9601000269, 2.9900249462, 3.9401501077}),
+					WeightH: mat.NewDense(1, 2, []float64{0.9700750269, -0.9700750269}),
+					Bias:    mat.NewVecDense(2, []float64{0.480000054, -0.5}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			optimizer := optimizers.InitAdamW(tt.lr, tt.beta1, tt.beta2, tt.decay)
+			got := tt.params
+			for i := 0; i < tt.steps; i++ {
+				got = optimizer.Update(got, tt.grads)
+			}
+			for i, w := range tt.wantParams {
+				if !mat.EqualApprox(got[i].Weight, w.Weight, 1e-8) {
+					t.Errorf("unexpected weight: want = %v, got = %v", w.Weight, got[i].Weight)
+				}
+				if !mat.EqualApprox(got[i].WeightH, w.WeightH, 1e-8) {
+					t.Errorf("unexpected weightH: want = %v, got = %v", w.WeightH, got[i].WeightH)
+				}
+				if !mat.EqualApprox(got[i].Bias, w.Bias, 1e-8) {
+					t.Errorf("unexpected bias: want = %v, got = %v", w.Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package optimizers
+
+import (
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+// DecayRule returns weight decay rate of field f in i-th params. 0 means no decay.
+// i is index of params passed to Update, so params can be grouped by index.
+type DecayRule func(i int, f params.Field) float64
+
+// WeightDecay returns DecayRule which decays weight & recurrent weight by rate. biases are excluded.
+func WeightDecay(rate float64) DecayRule {
+	return func(i int, f params.Field) float64 {
+		if f == params.FieldBias {
+			return 0
+		}
+		return rate
+	}
+}
+
+// addL2 returns grads added gradient of L2 regularization (rate * param).
+func addL2(ps []params.Param, grads []params.Grad, rule DecayRule) []params.Grad {
+	result := make([]params.Grad, len(grads))
+	for i, g := range grads {
+		result[i] = g
+		p := ps[i]
+		if rate := rule(i, params.FieldWeight); rate != 0 && p.Weight != nil {
+			result[i].Weight = addScaled(g.Weight, p.Weight, rate)
+		}
+		if rate := rule(i, params.FieldWeightH); rate != 0 && p.WeightH != nil {
+			result[i].WeightH = addScaled(g.WeightH, p.WeightH, rate)
+		}
+		if rate := rule(i, params.FieldBias); rate != 0 && p.Bias != nil {
+			b := mat.NewVecDense(p.Bias.Len(), nil)
+			b.AddScaledVec(g.Bias, rate, p.Bias)
+			result[i].Bias = b
+		}
+	}
+	return result
+}
+
+// decay returns params shrunk by lr * rate (decoupled weight decay).
+func decay(ps []params.Param, rule DecayRule, lr float64) []params.Param {
+	result := make([]params.Param, len(ps))
+	for i, p := range ps {
+		result[i] = p
+		if rate := rule(i, params.FieldWeight); rate != 0 && p.Weight != nil {
+			result[i].Weight = addScaled(p.Weight, p.Weight, -lr*rate)
+		}
+		if rate := rule(i, params.FieldWeightH); rate != 0 && p.WeightH != nil {
+			result[i].WeightH = addScaled(p.WeightH, p.WeightH, -lr*rate)
+		}
+		if rate := rule(i, params.FieldBias); rate != 0 && p.Bias != nil {
+			b := mat.NewVecDense(p.Bias.Len(), nil)
+			b.AddScaledVec(p.Bias, -lr*rate, p.Bias)
+			result[i].Bias = b
+		}
+	}
+	return result
+}
+
+// addScaled returns x + alpha * y.
+func addScaled(x, y mat.Matrix, alpha float64) *mat.Dense {
+	r, c := x.Dims()
+	d := mat.NewDense(r, c, nil)
+	d.Scale(alpha, y)
+	d.Add(x, d)
+	return d
+}
@@ -0,0 +1,52 @@
+package optimizers
+
+import (
+	"github.com/po3rin/gonnp/params"
+)
+
+// Momentum has setting for Momentum SGD.
+// Decay adds L2 regularization to gradient if it is set.
+type Momentum struct {
+	LR       float64
+	Momentum float64
+	Decay    DecayRule
+	V        []params.Grad
+}
+
+// InitMomentum inits Momentum optimizer.
+func InitMomentum(lr, momentum float64) *Momentum {
+	return &Momentum{
+		LR:       lr,
+		Momentum: momentum,
+	}
+}
+
+// Update updates params using Momentum argolism.
+// weight, recurrent weight and bias are updated if param has them.
+func (m *Momentum) Update(ps []params.Param, grads []params.Grad) []params.Param {
+	m.V = prepareState(m.V, ps)
+	if m.Decay != nil {
+		grads = addL2(ps, grads, m.Decay)
+	}
+
+	f := func(p, g float64, s []float64) float64 {
+		s[0] = m.Momentum*s[0] - m.LR*g
+		return p + s[0]
+	}
+
+	result := make([]params.Param, len(ps))
+	for i := range ps {
+		result[i] = update(ps[i], grads[i], f, m.V[i])
+	}
+	return result
+}
+
+// GetLR returns learning rate.
+func (m *Momentum) GetLR() float64 {
+	return m.LR
+}
+
+// SetLR sets learning rate.
+func (m *Momentum) SetLR(lr float64) {
+	m.LR = lr
+}
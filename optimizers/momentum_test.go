@@ -0,0 +1,130 @@
+// +build !e2e
+
+package optimizers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMomentumUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		lr         float64
+		momentum   float64
+		decay      optimizers.DecayRule
+		steps      int
+		params     []params.Param
+		grads      []params.Grad
+		wantParams []params.Param
+	}{
+		{
+			name:     "1 step",
+			lr:       0.1,
+			momentum: 0.9,
+			steps:    1,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.9, 1.8, 3.1, 3.95}),
+					WeightH: mat.NewDense(1, 2, []float64{0.8, -0.8}),
+					Bias:    mat.NewVecDense(2, []float64{0.4, -0.5}),
+				},
+			},
+		},
+		{
+			name:     "2 steps",
+			lr:       0.1,
+			momentum: 0.9,
+			steps:    2,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.71, 1.42, 3.29, 3.855}),
+					WeightH: mat.NewDense(1, 2, []float64{0.42, -0.42}),
+					Bias:    mat.NewVecDense(2, []float64{0.21, -0.5}),
+				},
+			},
+		},
+		{
+			name:     "2 steps with weight decay",
+			lr:       0.1,
+			momentum: 0.9,
+			decay:    optimizers.WeightDecay(0.5),
+			steps:    2,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.5725, 1.145, 2.8575, 3.2875}),
+					WeightH: mat.NewDense(1, 2, []float64{0.2875, -0.2875}),
+					Bias:    mat.NewVecDense(2, []float64{0.21, -0.5}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			optimizer := optimizers.InitMomentum(tt.lr, tt.momentum)
+			optimizer.Decay = tt.decay
+			got := tt.params
+			for i := 0; i < tt.steps; i++ {
+				got = optimizer.Update(got, tt.grads)
+			}
+			for i, w := range tt.wantParams {
+				if !mat.EqualApprox(got[i].Weight, w.Weight, 1e-7) {
+					t.Errorf("unexpected weight: want = %v, got = %v", w.Weight, got[i].Weight)
+				}
+				if !mat.EqualApprox(got[i].WeightH, w.WeightH, 1e-7) {
+					t.Errorf("unexpected weightH: want = %v, got = %v", w.WeightH, got[i].WeightH)
+				}
+				if !mat.EqualApprox(got[i].Bias, w.Bias, 1e-7) {
+					t.Errorf("unexpected bias: want = %v, got = %v", w.Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package optimizers
+
+import (
+	"github.com/po3rin/gonnp/params"
+)
+
+// Nesterov has setting for Nesterov's Accelerated Gradient.
+type Nesterov struct {
+	LR       float64
+	Momentum float64
+	V        []params.Grad
+}
+
+// InitNesterov inits Nesterov optimizer.
+func InitNesterov(lr, momentum float64) *Nesterov {
+	return &Nesterov{
+		LR:       lr,
+		Momentum: momentum,
+	}
+}
+
+// Update updates params using Nesterov argolism.
+// weight, recurrent weight and bias are updated if param has them.
+func (n *Nesterov) Update(ps []params.Param, grads []params.Grad) []params.Param {
+	n.V = prepareState(n.V, ps)
+
+	f := func(p, g float64, s []float64) float64 {
+		s[0] = n.Momentum*s[0] - n.LR*g
+		return p + n.Momentum*n.Momentum*s[0] - (1+n.Momentum)*n.LR*g
+	}
+
+	result := make([]params.Param, len(ps))
+	for i := range ps {
+		result[i] = update(ps[i], grads[i], f, n.V[i])
+	}
+	return result
+}
+
+// GetLR returns learning rate.
+func (n *Nesterov) GetLR() float64 {
+	return n.LR
+}
+
+// SetLR sets learning rate.
+func (n *Nesterov) SetLR(lr float64) {
+	n.LR = lr
+}
@@ -0,0 +1,100 @@
+// +build !e2e
+
+package optimizers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestNesterovUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		lr         float64
+		momentum   float64
+		steps      int
+		params     []params.Param
+		grads      []params.Grad
+		wantParams []params.Param
+	}{
+		{
+			name:     "1 step",
+			lr:       0.1,
+			momentum: 0.9,
+			steps:    1,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.729, 1.458, 3.271, 3.8645}),
+					WeightH: mat.NewDense(1, 2, []float64{0.458, -0.458}),
+					Bias:    mat.NewVecDense(2, []float64{0.229, -0.5}),
+				},
+			},
+		},
+		{
+			name:     "2 steps",
+			lr:       0.1,
+			momentum: 0.9,
+			steps:    2,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.3851, 0.7702, 3.6149, 3.69255}),
+					WeightH: mat.NewDense(1, 2, []float64{-0.2298, 0.2298}),
+					Bias:    mat.NewVecDense(2, []float64{-0.1149, -0.5}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			optimizer := optimizers.InitNesterov(tt.lr, tt.momentum)
+			got := tt.params
+			for i := 0; i < tt.steps; i++ {
+				got = optimizer.Update(got, tt.grads)
+			}
+			for i, w := range tt.wantParams {
+				if !mat.EqualApprox(got[i].Weight, w.Weight, 1e-7) {
+					t.Errorf("unexpected weight: want = %v, got = %v", w.Weight, got[i].Weight)
+				}
+				if !mat.EqualApprox(got[i].WeightH, w.WeightH, 1e-7) {
+					t.Errorf("unexpected weightH: want = %v, got = %v", w.WeightH, got[i].WeightH)
+				}
+				if !mat.EqualApprox(got[i].Bias, w.Bias, 1e-7) {
+					t.Errorf("unexpected bias: want = %v, got = %v", w.Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
@@ -1,2 +1,125 @@
 // Package optimizers updates params (ex. weight, bias ...) using various algorism.
 package optimizers
+
+import (
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+// elemFunc returns new param value from param p & gradient g.
+// s has state values of optimizer for the element and it is updated in place.
+type elemFunc func(p, g float64, s []float64) float64
+
+// zerosLike returns zero state which has same shapes as p.
+func zerosLike(p params.Param) params.Grad {
+	var s params.Grad
+	if p.Weight != nil {
+		r, c := p.Weight.Dims()
+		s.Weight = mat.NewDense(r, c, nil)
+	}
+	if p.WeightH != nil {
+		r, c := p.WeightH.Dims()
+		s.WeightH = mat.NewDense(r, c, nil)
+	}
+	if p.Bias != nil {
+		s.Bias = mat.NewVecDense(p.Bias.Len(), nil)
+	}
+	return s
+}
+
+// sameShape reports whether state s has same shapes as p.
+func sameShape(s params.Grad, p params.Param) bool {
+	if (s.Weight == nil) != (p.Weight == nil) || (s.WeightH == nil) != (p.WeightH == nil) || (s.Bias == nil) != (p.Bias == nil) {
+		return false
+	}
+	if p.Weight != nil {
+		sr, sc := s.Weight.Dims()
+		pr, pc := p.Weight.Dims()
+		if sr != pr || sc != pc {
+			return false
+		}
+	}
+	if p.WeightH != nil {
+		sr, sc := s.WeightH.Dims()
+		pr, pc := p.WeightH.Dims()
+		if sr != pr || sc != pc {
+			return false
+		}
+	}
+	if p.Bias != nil && s.Bias.Len() != p.Bias.Len() {
+		return false
+	}
+	return true
+}
+
+// prepareState returns state which has an entry for each params.
+// state is keyed by index of params, so entries are kept across calls
+// and re-initialized only when shapes of params change.
+func prepareState(state []params.Grad, ps []params.Param) []params.Grad {
+	for len(state) < len(ps) {
+		state = append(state, params.Grad{})
+	}
+	for i, p := range ps {
+		if !sameShape(state[i], p) {
+			state[i] = zerosLike(p)
+		}
+	}
+	return state
+}
+
+// update applies f to each element of weight, recurrent weight and bias, and returns new param.
+// states are updated in place.
+func update(p params.Param, g params.Grad, f elemFunc, states ...params.Grad) params.Param {
+	var result params.Param
+	s := make([]float64, len(states))
+
+	if p.Weight != nil {
+		ms := make([]*mat.Dense, len(states))
+		for k := range states {
+			ms[k] = states[k].Weight.(*mat.Dense)
+		}
+		result.Weight = updateMat(p.Weight, g.Weight, ms, s, f)
+	}
+
+	if p.WeightH != nil {
+		ms := make([]*mat.Dense, len(states))
+		for k := range states {
+			ms[k] = states[k].WeightH.(*mat.Dense)
+		}
+		result.WeightH = updateMat(p.WeightH, g.WeightH, ms, s, f)
+	}
+
+	if p.Bias != nil {
+		l := p.Bias.Len()
+		b := mat.NewVecDense(l, nil)
+		for i := 0; i < l; i++ {
+			for k := range states {
+				s[k] = states[k].Bias.AtVec(i)
+			}
+			b.SetVec(i, f(p.Bias.AtVec(i), g.Bias.AtVec(i), s))
+			for k := range states {
+				states[k].Bias.(*mat.VecDense).SetVec(i, s[k])
+			}
+		}
+		result.Bias = b
+	}
+
+	return result
+}
+
+func updateMat(p, g mat.Matrix, states []*mat.Dense, s []float64, f elemFunc) *mat.Dense {
+	r, c := p.Dims()
+	d := mat.NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			for k, m := range states {
+				s[k] = m.At(i, j)
+			}
+			d.Set(i, j, f(p.At(i, j), g.At(i, j), s))
+			for k, m := range states {
+				m.Set(i, j, s[k])
+			}
+		}
+	}
+	return d
+}
@@ -0,0 +1,49 @@
+package optimizers
+
+import (
+	"math"
+
+	"github.com/po3rin/gonnp/params"
+)
+
+// RMSProp has setting for RMSProp optimizer.
+type RMSProp struct {
+	LR        float64
+	DecayRate float64
+	H         []params.Grad
+}
+
+// InitRMSProp inits RMSProp optimizer.
+func InitRMSProp(lr, decayRate float64) *RMSProp {
+	return &RMSProp{
+		LR:        lr,
+		DecayRate: decayRate,
+	}
+}
+
+// Update updates params using RMSProp argolism.
+// weight, recurrent weight and bias are updated if param has them.
+func (r *RMSProp) Update(ps []params.Param, grads []params.Grad) []params.Param {
+	r.H = prepareState(r.H, ps)
+
+	f := func(p, g float64, s []float64) float64 {
+		s[0] = r.DecayRate*s[0] + (1-r.DecayRate)*g*g
+		return p - r.LR*g/(math.Sqrt(s[0])+1e-7)
+	}
+
+	result := make([]params.Param, len(ps))
+	for i := range ps {
+		result[i] = update(ps[i], grads[i], f, r.H[i])
+	}
+	return result
+}
+
+// GetLR returns learning rate.
+func (r *RMSProp) GetLR() float64 {
+	return r.LR
+}
+
+// SetLR sets learning rate.
+func (r *RMSProp) SetLR(lr float64) {
+	r.LR = lr
+}
@@ -0,0 +1,100 @@
+// +build !e2e
+
+package optimizers_test
+
+import (
+	"testing"
+
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestRMSPropUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		lr         float64
+		decayRate  float64
+		steps      int
+		params     []params.Param
+		grads      []params.Grad
+		wantParams []params.Param
+	}{
+		{
+			name:      "1 step",
+			lr:        0.1,
+			decayRate: 0.99,
+			steps:     1,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.000001, 1.0000005, 3.999999, 3.000002}),
+					WeightH: mat.NewDense(1, 2, []float64{0.0000005, -0.0000005}),
+					Bias:    mat.NewVecDense(2, []float64{-0.499999, -0.5}),
+				},
+			},
+		},
+		{
+			name:      "2 steps",
+			lr:        0.1,
+			decayRate: 0.99,
+			steps:     2,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{-0.7088797025, 0.2911195462, 4.7088797025, 2.2911218}),
+					WeightH: mat.NewDense(1, 2, []float64{-0.7088804538, 0.7088804538}),
+					Bias:    mat.NewVecDense(2, []float64{-1.2088797025, -0.5}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			optimizer := optimizers.InitRMSProp(tt.lr, tt.decayRate)
+			got := tt.params
+			for i := 0; i < tt.steps; i++ {
+				got = optimizer.Update(got, tt.grads)
+			}
+			for i, w := range tt.wantParams {
+				if !mat.EqualApprox(got[i].Weight, w.Weight, 1e-7) {
+					t.Errorf("unexpected weight: want = %v, got = %v", w.Weight, got[i].Weight)
+				}
+				if !mat.EqualApprox(got[i].WeightH, w.WeightH, 1e-7) {
+					t.Errorf("unexpected weightH: want = %v, got = %v", w.WeightH, got[i].WeightH)
+				}
+				if !mat.EqualApprox(got[i].Bias, w.Bias, 1e-7) {
+					t.Errorf("unexpected bias: want = %v, got = %v", w.Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
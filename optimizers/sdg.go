@@ -6,8 +6,10 @@ import (
 )
 
 // SDG has setting for Stochastic Gradient Descent.
+// Decay adds L2 regularization to gradient if it is set.
 type SDG struct {
-	LR float64
+	LR    float64
+	Decay DecayRule
 }
 
 // InitSDG inits SDG setting.
@@ -19,27 +21,46 @@ func InitSDG(lr float64) *SDG {
 }
 
 // Update updates prams using gradient.
+// weight, recurrent weight and bias are updated if param has them.
 func (s *SDG) Update(params []params.Param, grads []params.Grad) []params.Param {
+	if s.Decay != nil {
+		grads = addL2(params, grads, s.Decay)
+	}
 	for n := 0; n < len(params); n++ {
 		wr, wc := grads[n].Weight.Dims()
-		l := grads[n].Bias.Len()
-
 		tmpW := mat.NewDense(wr, wc, nil)
-		tmpB := mat.NewVecDense(l, nil)
-
 		tmpW.Scale(s.LR, grads[n].Weight)
-		tmpB.ScaleVec(s.LR, grads[n].Bias)
 
 		wr, wc = params[n].Weight.Dims()
-		l = params[n].Bias.Len()
 		W := mat.NewDense(wr, wc, nil)
-		B := mat.NewVecDense(l, nil)
-
 		W.Sub(params[n].Weight, tmpW)
-		B.SubVec(params[n].Bias, tmpB)
-
 		params[n].Weight = W
-		params[n].Bias = B
+
+		if params[n].WeightH != nil {
+			hr, hc := params[n].WeightH.Dims()
+			WH := mat.NewDense(hr, hc, nil)
+			WH.Scale(s.LR, grads[n].WeightH)
+			WH.Sub(params[n].WeightH, WH)
+			params[n].WeightH = WH
+		}
+
+		if params[n].Bias != nil {
+			l := params[n].Bias.Len()
+			B := mat.NewVecDense(l, nil)
+			B.ScaleVec(s.LR, grads[n].Bias)
+			B.SubVec(params[n].Bias, B)
+			params[n].Bias = B
+		}
 	}
 	return params
 }
+
+// GetLR returns learning rate.
+func (s *SDG) GetLR() float64 {
+	return s.LR
+}
+
+// SetLR sets learning rate.
+func (s *SDG) SetLR(lr float64) {
+	s.LR = lr
+}
@@ -104,3 +104,169 @@ func TestSDGUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestSDGUpdateRecurrent(t *testing.T) {
+	tests := []struct {
+		name       string
+		lr         float64
+		params     []params.Param
+		grads      []params.Grad
+		wantParams []params.Param
+	}{
+		{
+			name: "recurrent weight",
+			lr:   0.1,
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.9, 1.8, 3.1, 3.95}),
+					WeightH: mat.NewDense(1, 2, []float64{0.8, -0.8}),
+					Bias:    mat.NewVecDense(2, []float64{0.4, -0.5}),
+				},
+			},
+		},
+		{
+			name: "without bias",
+			lr:   0.1,
+			params: []params.Param{
+				params.Param{
+					Weight: mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight: mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight: mat.NewDense(2, 2, []float64{0.9, 1.8, 3.1, 3.95}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			optimizer := optimizers.InitSDG(tt.lr)
+			got := optimizer.Update(tt.params, tt.grads)
+			for i, w := range tt.wantParams {
+				if !mat.EqualApprox(w.Weight, got[i].Weight, 1e-14) {
+					t.Errorf("unexpected weight: want = %v, got = %v\n", w.Weight, got[i].Weight)
+				}
+				if w.WeightH != nil && !mat.EqualApprox(w.WeightH, got[i].WeightH, 1e-14) {
+					t.Errorf("unexpected weightH: want = %v, got = %v\n", w.WeightH, got[i].WeightH)
+				}
+				if (w.Bias == nil) != (got[i].Bias == nil) {
+					t.Fatalf("unexpected bias: want = %v, got = %v\n", w.Bias, got[i].Bias)
+				}
+				if w.Bias != nil && !mat.EqualApprox(w.Bias, got[i].Bias, 1e-14) {
+					t.Errorf("unexpected bias: want = %v, got = %v\n", w.Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
+
+func TestSDGUpdateWithDecay(t *testing.T) {
+	tests := []struct {
+		name       string
+		lr         float64
+		decay      optimizers.DecayRule
+		params     []params.Param
+		grads      []params.Grad
+		wantParams []params.Param
+	}{
+		{
+			name:  "bias is excluded",
+			lr:    0.1,
+			decay: optimizers.WeightDecay(0.5),
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.85, 1.7, 2.95, 3.75}),
+					WeightH: mat.NewDense(1, 2, []float64{0.75, -0.75}),
+					Bias:    mat.NewVecDense(2, []float64{0.4, -0.5}),
+				},
+			},
+		},
+		{
+			name: "custom rule",
+			lr:   0.1,
+			decay: func(i int, f params.Field) float64 {
+				if f == params.FieldBias {
+					return 0.5
+				}
+				return 0
+			},
+			params: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+					WeightH: mat.NewDense(1, 2, []float64{1, -1}),
+					Bias:    mat.NewVecDense(2, []float64{0.5, -0.5}),
+				},
+			},
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{1, 2, -1, 0.5}),
+					WeightH: mat.NewDense(1, 2, []float64{2, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+			},
+			wantParams: []params.Param{
+				params.Param{
+					Weight:  mat.NewDense(2, 2, []float64{0.9, 1.8, 3.1, 3.95}),
+					WeightH: mat.NewDense(1, 2, []float64{0.8, -0.8}),
+					Bias:    mat.NewVecDense(2, []float64{0.375, -0.475}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			optimizer := optimizers.InitSDG(tt.lr)
+			optimizer.Decay = tt.decay
+			got := optimizer.Update(tt.params, tt.grads)
+			for i, w := range tt.wantParams {
+				if !mat.EqualApprox(w.Weight, got[i].Weight, 1e-14) {
+					t.Errorf("unexpected weight: want = %v, got = %v\n", w.Weight, got[i].Weight)
+				}
+				if !mat.EqualApprox(w.WeightH, got[i].WeightH, 1e-14) {
+					t.Errorf("unexpected weightH: want = %v, got = %v\n", w.WeightH, got[i].WeightH)
+				}
+				if !mat.EqualApprox(w.Bias, got[i].Bias, 1e-14) {
+					t.Errorf("unexpected bias: want = %v, got = %v\n", w.Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
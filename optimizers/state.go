@@ -0,0 +1,94 @@
+package optimizers
+
+import (
+	"github.com/po3rin/gonnp/params"
+)
+
+// State is exportable state of optimizer.
+// Slots has per-params state like M & V of Adam. each slot is keyed by index of params.
+type State struct {
+	LR    float64
+	Iter  float64
+	Slots [][]params.Grad
+}
+
+// StateManager exports & imports state of optimizer.
+type StateManager interface {
+	GetState() State
+	SetState(s State)
+}
+
+// GetState returns state of SDG.
+func (s *SDG) GetState() State {
+	return State{LR: s.LR}
+}
+
+// SetState sets state of SDG.
+func (s *SDG) SetState(st State) {
+	s.LR = st.LR
+}
+
+// GetState returns state of Momentum.
+func (m *Momentum) GetState() State {
+	return State{LR: m.LR, Slots: [][]params.Grad{m.V}}
+}
+
+// SetState sets state of Momentum.
+func (m *Momentum) SetState(st State) {
+	m.LR = st.LR
+	m.V = slot(st, 0)
+}
+
+// GetState returns state of Nesterov.
+func (n *Nesterov) GetState() State {
+	return State{LR: n.LR, Slots: [][]params.Grad{n.V}}
+}
+
+// SetState sets state of Nesterov.
+func (n *Nesterov) SetState(st State) {
+	n.LR = st.LR
+	n.V = slot(st, 0)
+}
+
+// GetState returns state of AdaGrad.
+func (a *AdaGrad) GetState() State {
+	return State{LR: a.LR, Slots: [][]params.Grad{a.H}}
+}
+
+// SetState sets state of AdaGrad.
+func (a *AdaGrad) SetState(st State) {
+	a.LR = st.LR
+	a.H = slot(st, 0)
+}
+
+// GetState returns state of RMSProp.
+func (r *RMSProp) GetState() State {
+	return State{LR: r.LR, Slots: [][]params.Grad{r.H}}
+}
+
+// SetState sets state of RMSProp.
+func (r *RMSProp) SetState(st State) {
+	r.LR = st.LR
+	r.H = slot(st, 0)
+}
+
+// GetState returns state of Adam.
+func (a *Adam) GetState() State {
+	return State{LR: a.LR, Iter: a.Iter, Slots: [][]params.Grad{a.M, a.V}}
+}
+
+// SetState sets state of Adam.
+func (a *Adam) SetState(st State) {
+	a.LR = st.LR
+	a.Iter = st.Iter
+	a.M = slot(st, 0)
+	a.V = slot(st, 1)
+}
+
+// slot returns i-th slot of state. it returns nil if state does not have it.
+func slot(st State, i int) []params.Grad {
+	if i >= len(st.Slots) {
+		return nil
+	}
+	return st.Slots[i]
+}
@@ -2,6 +2,8 @@
 package params
 
 import (
+	"reflect"
+
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -32,3 +34,32 @@ type Manager interface {
 	GetGrad() Grad
 	SetParam(p Param)
 }
+
+// Field is kind of tensor in Param.
+type Field int
+
+// fields of Param.
+const (
+	FieldWeight Field = iota
+	FieldWeightH
+	FieldBias
+)
+
+// Unique returns params without shared ones. params which have same weight are regarded as shared.
+// SetManager.UpdateParams receives params in this form.
+func Unique(ps []Param) []Param {
+	result := make([]Param, 0, len(ps))
+	for _, p := range ps {
+		var found bool
+		for _, u := range result {
+			if reflect.DeepEqual(p.Weight, u.Weight) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, p)
+		}
+	}
+	return result
+}
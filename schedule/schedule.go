@@ -0,0 +1,153 @@
+// Package schedule has learning rate schedulers.
+package schedule
+
+import (
+	"math"
+)
+
+// Scheduler returns learning rate from initial learning rate & progress of training.
+// epoch & iter are counted from 0. iter is total iterations over epochs.
+type Scheduler interface {
+	LR(initLR float64, epoch, iter int) float64
+}
+
+// Observer is Scheduler which observes metric at the end of each epoch.
+type Observer interface {
+	Observe(metric float64)
+}
+
+// StepDecay decays learning rate by Gamma every StepSize epochs.
+type StepDecay struct {
+	StepSize int
+	Gamma    float64
+}
+
+// InitStepDecay inits StepDecay scheduler. it panics if stepSize is not positive.
+func InitStepDecay(stepSize int, gamma float64) *StepDecay {
+	if stepSize <= 0 {
+		panic("gonnp: step size of StepDecay should be positive")
+	}
+	return &StepDecay{
+		StepSize: stepSize,
+		Gamma:    gamma,
+	}
+}
+
+// LR returns initLR * Gamma ^ (epoch / StepSize).
+func (s *StepDecay) LR(initLR float64, epoch, iter int) float64 {
+	return initLR * math.Pow(s.Gamma, float64(epoch/s.StepSize))
+}
+
+// ExponentialDecay decays learning rate by Gamma every epoch.
+type ExponentialDecay struct {
+	Gamma float64
+}
+
+// InitExponentialDecay inits ExponentialDecay scheduler.
+func InitExponentialDecay(gamma float64) *ExponentialDecay {
+	return &ExponentialDecay{
+		Gamma: gamma,
+	}
+}
+
+// LR returns initLR * Gamma ^ epoch.
+func (e *ExponentialDecay) LR(initLR float64, epoch, iter int) float64 {
+	return initLR * math.Pow(e.Gamma, float64(epoch))
+}
+
+// CosineAnnealing anneals learning rate from initial learning rate to MinLR over TMax epochs.
+type CosineAnnealing struct {
+	TMax  int
+	MinLR float64
+}
+
+// InitCosineAnnealing inits CosineAnnealing scheduler.
+func InitCosineAnnealing(tMax int, minLR float64) *CosineAnnealing {
+	return &CosineAnnealing{
+		TMax:  tMax,
+		MinLR: minLR,
+	}
+}
+
+// LR returns MinLR + (initLR - MinLR) * (1 + cos(pi * epoch / TMax)) / 2.
+// MinLR is kept after TMax epochs.
+func (c *CosineAnnealing) LR(initLR float64, epoch, iter int) float64 {
+	if epoch >= c.TMax {
+		return c.MinLR
+	}
+	return c.MinLR + (initLR-c.MinLR)*(1+math.Cos(math.Pi*float64(epoch)/float64(c.TMax)))/2
+}
+
+// LinearWarmup increases learning rate linearly for WarmupIters iterations,
+// then After is used. initial learning rate is used after warmup if After is nil.
+type LinearWarmup struct {
+	WarmupIters int
+	After       Scheduler
+}
+
+// InitLinearWarmup inits LinearWarmup scheduler.
+func InitLinearWarmup(warmupIters int, after Scheduler) *LinearWarmup {
+	return &LinearWarmup{
+		WarmupIters: warmupIters,
+		After:       after,
+	}
+}
+
+// LR returns initLR * (iter + 1) / WarmupIters while warmup.
+func (l *LinearWarmup) LR(initLR float64, epoch, iter int) float64 {
+	if iter < l.WarmupIters {
+		return initLR * float64(iter+1) / float64(l.WarmupIters)
+	}
+	if l.After == nil {
+		return initLR
+	}
+	return l.After.LR(initLR, epoch, iter)
+}
+
+// Observe passes metric to After if it is Observer.
+func (l *LinearWarmup) Observe(metric float64) {
+	if o, ok := l.After.(Observer); ok {
+		o.Observe(metric)
+	}
+}
+
+// ReduceOnPlateau reduces learning rate by Factor when metric has stopped improving for Patience epochs.
+// lower metric is better.
+type ReduceOnPlateau struct {
+	Factor   float64
+	Patience int
+	MinLR    float64
+	best     float64
+	wait     int
+	scale    float64
+}
+
+// InitReduceOnPlateau inits ReduceOnPlateau scheduler.
+func InitReduceOnPlateau(factor float64, patience int, minLR float64) *ReduceOnPlateau {
+	return &ReduceOnPlateau{
+		Factor:   factor,
+		Patience: patience,
+		MinLR:    minLR,
+		best:     math.Inf(1),
+		scale:    1,
+	}
+}
+
+// LR returns reduced learning rate. it is not less than MinLR.
+func (r *ReduceOnPlateau) LR(initLR float64, epoch, iter int) float64 {
+	return math.Max(initLR*r.scale, r.MinLR)
+}
+
+// Observe observes metric of the epoch.
+func (r *ReduceOnPlateau) Observe(metric float64) {
+	if metric < r.best {
+		r.best = metric
+		r.wait = 0
+		return
+	}
+	r.wait++
+	if r.wait > r.Patience {
+		r.scale *= r.Factor
+		r.wait = 0
+	}
+}
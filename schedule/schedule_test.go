@@ -0,0 +1,192 @@
+// +build !e2e
+
+package schedule_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/po3rin/gonnp/schedule"
+)
+
+type step struct {
+	epoch int
+	iter  int
+	want  float64
+}
+
+func TestScheduler(t *testing.T) {
+	tests := []struct {
+		name      string
+		scheduler schedule.Scheduler
+		initLR    float64
+		steps     []step
+	}{
+		{
+			name:      "step decay",
+			scheduler: schedule.InitStepDecay(2, 0.5),
+			initLR:    1,
+			steps: []step{
+				{epoch: 0, want: 1},
+				{epoch: 1, want: 1},
+				{epoch: 2, want: 0.5},
+				{epoch: 5, want: 0.25},
+			},
+		},
+		{
+			name:      "exponential decay",
+			scheduler: schedule.InitExponentialDecay(0.9),
+			initLR:    0.1,
+			steps: []step{
+				{epoch: 0, want: 0.1},
+				{epoch: 1, want: 0.09},
+				{epoch: 2, want: 0.081},
+			},
+		},
+		{
+			name:      "cosine annealing",
+			scheduler: schedule.InitCosineAnnealing(4, 0.1),
+			initLR:    1,
+			steps: []step{
+				{epoch: 0, want: 1},
+				{epoch: 1, want: 0.1 + 0.9*(1+math.Sqrt(2)/2)/2},
+				{epoch: 2, want: 0.55},
+				{epoch: 4, want: 0.1},
+				{epoch: 10, want: 0.1},
+			},
+		},
+		{
+			name:      "linear warmup",
+			scheduler: schedule.InitLinearWarmup(4, nil),
+			initLR:    1,
+			steps: []step{
+				{iter: 0, want: 0.25},
+				{iter: 1, want: 0.5},
+				{iter: 3, want: 1},
+				{iter: 100, want: 1},
+			},
+		},
+		{
+			name:      "linear warmup & step decay",
+			scheduler: schedule.InitLinearWarmup(2, schedule.InitStepDecay(1, 0.1)),
+			initLR:    1,
+			steps: []step{
+				{epoch: 0, iter: 0, want: 0.5},
+				{epoch: 0, iter: 1, want: 1},
+				{epoch: 1, iter: 2, want: 0.1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			for _, s := range tt.steps {
+				got := tt.scheduler.LR(tt.initLR, s.epoch, s.iter)
+				if math.Abs(got-s.want) > 1e-12 {
+					t.Errorf("epoch %v, iter %v: want = %v, got = %v", s.epoch, s.iter, s.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestInitStepDecayPanic(t *testing.T) {
+	for _, stepSize := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("step size %v: expected panic", stepSize)
+				}
+			}()
+			schedule.InitStepDecay(stepSize, 0.5)
+		}()
+	}
+}
+
+func TestReduceOnPlateau(t *testing.T) {
+	tests := []struct {
+		name     string
+		factor   float64
+		patience int
+		minLR    float64
+		metrics  []float64
+		want     []float64
+	}{
+		{
+			name:     "patience 1",
+			factor:   0.5,
+			patience: 1,
+			minLR:    0,
+			metrics:  []float64{3, 2, 2, 2, 1, 1.5, 1.5},
+			want:     []float64{1, 1, 1, 0.5, 0.5, 0.5, 0.25},
+		},
+		{
+			name:     "min lr",
+			factor:   0.1,
+			patience: 0,
+			minLR:    0.05,
+			metrics:  []float64{1, 1, 1},
+			want:     []float64{1, 0.1, 0.05},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := schedule.InitReduceOnPlateau(tt.factor, tt.patience, tt.minLR)
+			for i, m := range tt.metrics {
+				s.Observe(m)
+				got := s.LR(1, i+1, 0)
+				if math.Abs(got-tt.want[i]) > 1e-12 {
+					t.Errorf("%v: want = %v, got = %v", i, tt.want[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestReduceOnPlateauState(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics []float64
+		next    []float64
+	}{
+		{
+			name:    "waiting",
+			metrics: []float64{3, 2, 2},
+			next:    []float64{2, 1, 1},
+		},
+		{
+			name:    "reduced",
+			metrics: []float64{3, 3, 3},
+			next:    []float64{4, 4, 0.5},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := schedule.InitReduceOnPlateau(0.5, 1, 0)
+			for _, m := range tt.metrics {
+				s.Observe(m)
+			}
+
+			// LinearWarmup passes state to After.
+			resumed := schedule.InitLinearWarmup(0, schedule.InitReduceOnPlateau(0.5, 1, 0))
+			resumed.SetState(s.GetState())
+			if resumed.GetState() != s.GetState() {
+				t.Fatalf("want = %v, got = %v", s.GetState(), resumed.GetState())
+			}
+
+			for i, m := range tt.next {
+				s.Observe(m)
+				resumed.Observe(m)
+				want := s.LR(1, i, 0)
+				if got := resumed.LR(1, i, 0); math.Abs(got-want) > 1e-12 {
+					t.Errorf("%v: want = %v, got = %v", i, want, got)
+				}
+			}
+		})
+	}
+}
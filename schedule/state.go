@@ -0,0 +1,41 @@
+package schedule
+
+// State is exportable state of scheduler which observes metric.
+type State struct {
+	Best  float64
+	Wait  int
+	Scale float64
+}
+
+// StateManager exports & imports state of scheduler.
+type StateManager interface {
+	GetState() State
+	SetState(s State)
+}
+
+// GetState returns state of ReduceOnPlateau.
+func (r *ReduceOnPlateau) GetState() State {
+	return State{Best: r.best, Wait: r.wait, Scale: r.scale}
+}
+
+// SetState sets state of ReduceOnPlateau.
+func (r *ReduceOnPlateau) SetState(s State) {
+	r.best = s.Best
+	r.wait = s.Wait
+	r.scale = s.Scale
+}
+
+// GetState returns state of After if it is StateManager.
+func (l *LinearWarmup) GetState() State {
+	if m, ok := l.After.(StateManager); ok {
+		return m.GetState()
+	}
+	return State{}
+}
+
+// SetState sets state of After if it is StateManager.
+func (l *LinearWarmup) SetState(s State) {
+	if m, ok := l.After.(StateManager); ok {
+		m.SetState(s)
+	}
+}
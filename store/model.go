@@ -0,0 +1,197 @@
+package store
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/po3rin/gonnp/params"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Tensor is stored matrix with shape. vector is stored as (n, 1) matrix.
+type Tensor struct {
+	Rows int
+	Cols int
+	Data []float64
+}
+
+// ParamData is stored params.Param. nil field means param does not have it.
+type ParamData struct {
+	Weight  *Tensor
+	WeightH *Tensor
+	Bias    *Tensor
+}
+
+// ModelData is stored model. Type is type name of model like "*models.TwoLayerNet".
+type ModelData struct {
+	Type   string
+	Params []ParamData
+}
+
+// SaveModel saves params of model to file with type name of model.
+// shared params are saved once as passed to UpdateParams.
+func SaveModel(fileName string, model params.SetManager, opts ...OptionFunc) error {
+	err := encodeFile(fileName, NewModelData(model), opts...)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to encode model")
+	}
+	return nil
+}
+
+// LoadModel loads params from file into model.
+// type name & shapes of params should be same as model.
+func LoadModel(fileName string, model params.SetManager) error {
+	var d ModelData
+	err := decodeFile(fileName, &d)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to decode model file")
+	}
+	return d.Restore(model)
+}
+
+// EncodeModelTo encodes params of model to w.
+func EncodeModelTo(w io.Writer, model params.SetManager, opts ...OptionFunc) error {
+	return NewModelData(model).EncodeTo(w, opts...)
+}
+
+// DecodeModelFrom decodes params from r into model.
+func DecodeModelFrom(r io.Reader, model params.SetManager) error {
+	var d ModelData
+	if err := d.DecodeFrom(r); err != nil {
+		return err
+	}
+	return d.Restore(model)
+}
+
+// NewModelData creates ModelData from model.
+func NewModelData(model params.SetManager) *ModelData {
+	ps := params.Unique(model.GetParams())
+	d := &ModelData{
+		Type:   typeName(model),
+		Params: make([]ParamData, len(ps)),
+	}
+	for i, p := range ps {
+		d.Params[i] = ParamData{
+			Weight:  matrixTensor(p.Weight),
+			WeightH: matrixTensor(p.WeightH),
+			Bias:    vectorTensor(p.Bias),
+		}
+	}
+	return d
+}
+
+// EncodeTo encodes ModelData to w.
+func (d *ModelData) EncodeTo(w io.Writer, opts ...OptionFunc) error {
+	err := encode(w, d, opts...)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to encode model")
+	}
+	return nil
+}
+
+// DecodeFrom decodes ModelData from r. gzip compressed data is also supported.
+func (d *ModelData) DecodeFrom(r io.Reader) error {
+	err := decode(r, d)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to decode model")
+	}
+	return nil
+}
+
+// Restore sets params into model after verifying type name & shapes.
+func (d *ModelData) Restore(model params.SetManager) error {
+	if t := typeName(model); d.Type != t {
+		return errors.Errorf("gonnp: model type mismatch: file has %v, but model is %v", d.Type, t)
+	}
+	return restoreParams(model, d.Params)
+}
+
+// restoreParams sets params into model after verifying number & shapes.
+func restoreParams(model params.SetManager, pds []ParamData) error {
+	ps := params.Unique(model.GetParams())
+	if len(ps) != len(pds) {
+		return errors.Errorf("gonnp: number of params mismatch: file has %v, but model has %v", len(pds), len(ps))
+	}
+
+	result := make([]params.Param, len(ps))
+	for i, p := range ps {
+		pd := pds[i]
+		if err := verifyMatrix(pd.Weight, p.Weight); err != nil {
+			return errors.Wrapf(err, "gonnp: params[%v].Weight", i)
+		}
+		if err := verifyMatrix(pd.WeightH, p.WeightH); err != nil {
+			return errors.Wrapf(err, "gonnp: params[%v].WeightH", i)
+		}
+		if err := verifyVector(pd.Bias, p.Bias); err != nil {
+			return errors.Wrapf(err, "gonnp: params[%v].Bias", i)
+		}
+
+		if pd.Weight != nil {
+			result[i].Weight = mat.NewDense(pd.Weight.Rows, pd.Weight.Cols, pd.Weight.Data)
+		}
+		if pd.WeightH != nil {
+			result[i].WeightH = mat.NewDense(pd.WeightH.Rows, pd.WeightH.Cols, pd.WeightH.Data)
+		}
+		if pd.Bias != nil {
+			result[i].Bias = mat.NewVecDense(pd.Bias.Rows, pd.Bias.Data)
+		}
+	}
+
+	model.UpdateParams(result)
+	return nil
+}
+
+func typeName(model params.SetManager) string {
+	return fmt.Sprintf("%T", model)
+}
+
+func matrixTensor(x mat.Matrix) *Tensor {
+	if x == nil {
+		return nil
+	}
+	r, c := x.Dims()
+	return &Tensor{
+		Rows: r,
+		Cols: c,
+		Data: mat.DenseCopyOf(x).RawMatrix().Data,
+	}
+}
+
+func vectorTensor(v mat.Vector) *Tensor {
+	if v == nil {
+		return nil
+	}
+	return &Tensor{
+		Rows: v.Len(),
+		Cols: 1,
+		Data: mat.VecDenseCopyOf(v).RawVector().Data,
+	}
+}
+
+func verifyMatrix(t *Tensor, x mat.Matrix) error {
+	if (t == nil) != (x == nil) {
+		return errors.New("existence mismatch")
+	}
+	if t == nil {
+		return nil
+	}
+	r, c := x.Dims()
+	if t.Rows != r || t.Cols != c || len(t.Data) != r*c {
+		return errors.Errorf("shape mismatch: file has (%v, %v), but model has (%v, %v)", t.Rows, t.Cols, r, c)
+	}
+	return nil
+}
+
+func verifyVector(t *Tensor, v mat.Vector) error {
+	if (t == nil) != (v == nil) {
+		return errors.New("existence mismatch")
+	}
+	if t == nil {
+		return nil
+	}
+	if t.Rows != v.Len() || t.Cols != 1 || len(t.Data) != v.Len() {
+		return errors.Errorf("shape mismatch: file has (%v), but model has (%v)", t.Rows, v.Len())
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// +build !e2e
+
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/store"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSaveModel(t *testing.T) {
+	tests := []struct {
+		name   string
+		model  params.SetManager
+		loaded params.SetManager
+	}{
+		{
+			name:   "two layer net",
+			model:  models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+			loaded: models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+		},
+		{
+			name:   "simple cbow with shared weight",
+			model:  models.InitSimpleCBOW(5, 3, matutil.NewRand(1)),
+			loaded: models.InitSimpleCBOW(5, 3, matutil.NewRand(1)),
+		},
+		{
+			name:   "rnnlm",
+			model:  models.InitRNNLM(5, 3, 4, matutil.NewRand(1)),
+			loaded: models.InitRNNLM(5, 3, 4, matutil.NewRand(1)),
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(dir, "model.gob")
+			if err := store.SaveModel(fileName, tt.model); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := store.LoadModel(fileName, tt.loaded); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := tt.model.GetParams()
+			got := tt.loaded.GetParams()
+			if len(got) != len(want) {
+				t.Fatalf("want = %v, got = %v", len(want), len(got))
+			}
+			for i := range want {
+				if !mat.Equal(want[i].Weight, got[i].Weight) {
+					t.Errorf("weight %v: want = %v, got = %v", i, want[i].Weight, got[i].Weight)
+				}
+				if want[i].WeightH != nil && !mat.Equal(want[i].WeightH, got[i].WeightH) {
+					t.Errorf("weightH %v: want = %v, got = %v", i, want[i].WeightH, got[i].WeightH)
+				}
+				if want[i].Bias != nil && !mat.Equal(want[i].Bias, got[i].Bias) {
+					t.Errorf("bias %v: want = %v, got = %v", i, want[i].Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadModelError(t *testing.T) {
+	tests := []struct {
+		name   string
+		model  params.SetManager
+		loaded params.SetManager
+	}{
+		{
+			name:   "shape mismatch",
+			model:  models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+			loaded: models.NewTwoLayerNet(4, 5, 2, matutil.NewRand(1)),
+		},
+		{
+			name:   "type mismatch",
+			model:  models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+			loaded: models.InitRNNLM(4, 3, 2, matutil.NewRand(1)),
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(dir, "model.gob")
+			if err := store.SaveModel(fileName, tt.model); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			before := mat.DenseCopyOf(tt.loaded.GetParams()[0].Weight)
+			if err := store.LoadModel(fileName, tt.loaded); err == nil {
+				t.Fatal("expected error")
+			}
+			if !mat.Equal(before, tt.loaded.GetParams()[0].Weight) {
+				t.Error("params are changed by failed load")
+			}
+		})
+	}
+}
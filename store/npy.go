@@ -0,0 +1,225 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// npyMagic is magic string of NumPy .npy format.
+const npyMagic = "\x93NUMPY"
+
+var (
+	npyDescr   = regexp.MustCompile(`'descr':\s*'([^']*)'`)
+	npyFortran = regexp.MustCompile(`'fortran_order':\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`'shape':\s*\(([^)]*)\)`)
+)
+
+// npyArray is n-dimensional array in .npy file. data is in C order.
+type npyArray struct {
+	shape []int
+	data  []float64
+}
+
+// WriteNpy writes m to w in NumPy .npy format. it is loaded by np.load as float64 array of shape (rows, cols).
+func WriteNpy(w io.Writer, m mat.Matrix) error {
+	r, c := m.Dims()
+	err := writeNpy(w, npyArray{
+		shape: []int{r, c},
+		data:  mat.DenseCopyOf(m).RawMatrix().Data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to write npy")
+	}
+	return nil
+}
+
+// ReadNpy reads NumPy .npy format from r. float32, float64, int32 & int64 arrays are supported.
+// 1-D array of shape (n,) is read as (1, n) matrix.
+func ReadNpy(r io.Reader) (*mat.Dense, error) {
+	a, err := readNpy(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "gonnp: failed to read npy")
+	}
+	switch len(a.shape) {
+	case 1:
+		return mat.NewDense(1, a.shape[0], a.data), nil
+	case 2:
+		return mat.NewDense(a.shape[0], a.shape[1], a.data), nil
+	}
+	return nil, errors.Errorf("gonnp: %v-D array is not supported", len(a.shape))
+}
+
+func writeNpy(w io.Writer, a npyArray) error {
+	shape := make([]string, len(a.shape))
+	for i, s := range a.shape {
+		shape[i] = strconv.Itoa(s)
+	}
+	s := strings.Join(shape, ", ")
+	if len(shape) == 1 {
+		s += ","
+	}
+
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': (%s), }", s)
+	// magic, version & header length takes 10 bytes. total header size is aligned by 64 bytes.
+	pad := 64 - (10+len(header)+1)%64
+	header += strings.Repeat(" ", pad%64) + "\n"
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(npyMagic)
+	bw.Write([]byte{1, 0})
+	binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	bw.WriteString(header)
+	if err := binary.Write(bw, binary.LittleEndian, a.data); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func readNpy(r io.Reader) (npyArray, error) {
+	var a npyArray
+
+	pre := make([]byte, 8)
+	if _, err := io.ReadFull(r, pre); err != nil {
+		return a, err
+	}
+	if string(pre[:6]) != npyMagic {
+		return a, errors.New("invalid magic string")
+	}
+
+	var headerLen int
+	switch pre[6] {
+	case 1:
+		var l uint16
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return a, err
+		}
+		headerLen = int(l)
+	case 2, 3:
+		var l uint32
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return a, err
+		}
+		headerLen = int(l)
+	default:
+		return a, errors.Errorf("version %v.%v is not supported", pre[6], pre[7])
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return a, err
+	}
+	descr, fortran, shape, err := parseNpyHeader(header)
+	if err != nil {
+		return a, err
+	}
+	a.shape = shape
+
+	size := 1
+	for _, s := range shape {
+		size *= s
+	}
+	a.data, err = readNpyData(r, descr, size)
+	if err != nil {
+		return a, err
+	}
+
+	if fortran && len(shape) == 2 {
+		d := mat.NewDense(shape[1], shape[0], a.data)
+		a.data = mat.DenseCopyOf(d.T()).RawMatrix().Data
+	} else if fortran && len(shape) > 2 {
+		return a, errors.New("fortran order of over 2-D array is not supported")
+	}
+	return a, nil
+}
+
+func parseNpyHeader(header []byte) (descr string, fortran bool, shape []int, err error) {
+	m := npyDescr.FindSubmatch(header)
+	if m == nil {
+		return "", false, nil, errors.New("descr is not found in header")
+	}
+	descr = string(m[1])
+
+	m = npyFortran.FindSubmatch(header)
+	if m == nil {
+		return "", false, nil, errors.New("fortran_order is not found in header")
+	}
+	fortran = string(m[1]) == "True"
+
+	m = npyShape.FindSubmatch(header)
+	if m == nil {
+		return "", false, nil, errors.New("shape is not found in header")
+	}
+	for _, s := range bytes.Split(m[1], []byte(",")) {
+		s = bytes.TrimSpace(s)
+		if len(s) == 0 {
+			continue
+		}
+		// shape may be written as python long like 3L.
+		n, err := strconv.Atoi(strings.TrimSuffix(string(s), "L"))
+		if err != nil {
+			return "", false, nil, errors.Wrap(err, "invalid shape")
+		}
+		shape = append(shape, n)
+	}
+	return descr, fortran, shape, nil
+}
+
+func readNpyData(r io.Reader, descr string, size int) ([]float64, error) {
+	if len(descr) != 3 {
+		return nil, errors.Errorf("dtype %v is not supported", descr)
+	}
+
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|', '=':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return nil, errors.Errorf("dtype %v is not supported", descr)
+	}
+
+	data := make([]float64, size)
+	switch descr[1:] {
+	case "f8":
+		if err := binary.Read(r, order, data); err != nil {
+			return nil, err
+		}
+	case "f4":
+		buf := make([]float32, size)
+		if err := binary.Read(r, order, buf); err != nil {
+			return nil, err
+		}
+		for i, v := range buf {
+			data[i] = float64(v)
+		}
+	case "i8":
+		buf := make([]int64, size)
+		if err := binary.Read(r, order, buf); err != nil {
+			return nil, err
+		}
+		for i, v := range buf {
+			data[i] = float64(v)
+		}
+	case "i4":
+		buf := make([]int32, size)
+		if err := binary.Read(r, order, buf); err != nil {
+			return nil, err
+		}
+		for i, v := range buf {
+			data[i] = float64(v)
+		}
+	default:
+		return nil, errors.Errorf("dtype %v is not supported", descr)
+	}
+	return data, nil
+}
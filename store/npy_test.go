@@ -0,0 +1,243 @@
+// +build !e2e
+
+package store_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/store"
+	"gonum.org/v1/gonum/mat"
+)
+
+// npyBytes makes .npy file of version 1.0 from header dict & data.
+func npyBytes(order binary.ByteOrder, header string, data interface{}) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\x93NUMPY")
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)+1))
+	buf.WriteString(header + "\n")
+	binary.Write(&buf, order, data)
+	return buf.Bytes()
+}
+
+func TestWriteNpy(t *testing.T) {
+	m := mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	var buf bytes.Buffer
+	if err := store.WriteNpy(&buf, m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b := buf.Bytes()
+	headerLen := int(binary.LittleEndian.Uint16(b[8:10]))
+	if (10+headerLen)%64 != 0 {
+		t.Errorf("header is not aligned: %v", 10+headerLen)
+	}
+	wantHeader := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }"
+	if got := string(b[10 : 10+len(wantHeader)]); got != wantHeader {
+		t.Errorf("want = %v, got = %v", wantHeader, got)
+	}
+
+	got, err := store.ReadNpy(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mat.Equal(got, m) {
+		t.Errorf("want = %v, got = %v", m, got)
+	}
+}
+
+func TestReadNpy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		want    *mat.Dense
+		wantErr bool
+	}{
+		{
+			name:  "float32",
+			input: npyBytes(binary.LittleEndian, "{'descr': '<f4', 'fortran_order': False, 'shape': (2, 2), }", []float32{1, 2, 3, 4}),
+			want:  mat.NewDense(2, 2, []float64{1, 2, 3, 4}),
+		},
+		{
+			name:  "big endian float64",
+			input: npyBytes(binary.BigEndian, "{'descr': '>f8', 'fortran_order': False, 'shape': (1, 2), }", []float64{0.5, -1}),
+			want:  mat.NewDense(1, 2, []float64{0.5, -1}),
+		},
+		{
+			name:  "int64 1-D",
+			input: npyBytes(binary.LittleEndian, "{'descr': '<i8', 'fortran_order': False, 'shape': (3,), }", []int64{1, 2, 3}),
+			want:  mat.NewDense(1, 3, []float64{1, 2, 3}),
+		},
+		{
+			name:  "fortran order",
+			input: npyBytes(binary.LittleEndian, "{'descr': '<f8', 'fortran_order': True, 'shape': (2, 3), }", []float64{1, 4, 2, 5, 3, 6}),
+			want:  mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			name:    "unsupported dtype",
+			input:   npyBytes(binary.LittleEndian, "{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }", []float64{1, 2}),
+			wantErr: true,
+		},
+		{
+			name:    "3-D",
+			input:   npyBytes(binary.LittleEndian, "{'descr': '<f8', 'fortran_order': False, 'shape': (1, 1, 1), }", []float64{1}),
+			wantErr: true,
+		},
+		{
+			name:    "short data",
+			input:   npyBytes(binary.LittleEndian, "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2), }", []float64{1}),
+			wantErr: true,
+		},
+		{
+			name:    "invalid magic",
+			input:   []byte("PK\x03\x04 not npy"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.ReadNpy(bytes.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !mat.Equal(got, tt.want) {
+				t.Errorf("want = %v, got = %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSaveNpz(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     params.SetManager
+		loaded    params.SetManager
+		wantNames []string
+	}{
+		{
+			name:      "two layer net",
+			model:     models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+			loaded:    models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+			wantNames: []string{"arr_0.npy", "arr_1.npy", "arr_2.npy", "arr_3.npy"},
+		},
+		{
+			name:      "simple cbow with shared weight",
+			model:     models.InitSimpleCBOW(5, 3, matutil.NewRand(1)),
+			loaded:    models.InitSimpleCBOW(5, 3, matutil.NewRand(1)),
+			wantNames: []string{"arr_0.npy", "arr_1.npy"},
+		},
+		{
+			name:   "rnnlm",
+			model:  models.InitRNNLM(5, 3, 4, matutil.NewRand(1)),
+			loaded: models.InitRNNLM(5, 3, 4, matutil.NewRand(1)),
+			// embed W, LSTM Wx, Wh, b & affine W, b.
+			wantNames: []string{"arr_0.npy", "arr_1.npy", "arr_2.npy", "arr_3.npy", "arr_4.npy", "arr_5.npy"},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(dir, "params.npz")
+			if err := store.SaveNpz(fileName, tt.model); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			zr, err := zip.OpenReader(fileName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, f := range zr.File {
+				names = append(names, f.Name)
+			}
+			zr.Close()
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("want = %v, got = %v", tt.wantNames, names)
+			}
+			for i := range names {
+				if names[i] != tt.wantNames[i] {
+					t.Errorf("want = %v, got = %v", tt.wantNames[i], names[i])
+				}
+			}
+
+			if err := store.LoadNpz(fileName, tt.loaded); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := tt.model.GetParams()
+			got := tt.loaded.GetParams()
+			for i := range want {
+				if !mat.Equal(want[i].Weight, got[i].Weight) {
+					t.Errorf("weight %v: want = %v, got = %v", i, want[i].Weight, got[i].Weight)
+				}
+				if want[i].WeightH != nil && !mat.Equal(want[i].WeightH, got[i].WeightH) {
+					t.Errorf("weightH %v: want = %v, got = %v", i, want[i].WeightH, got[i].WeightH)
+				}
+				if want[i].Bias != nil && !mat.Equal(want[i].Bias, got[i].Bias) {
+					t.Errorf("bias %v: want = %v, got = %v", i, want[i].Bias, got[i].Bias)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadNpzError(t *testing.T) {
+	tests := []struct {
+		name   string
+		model  params.SetManager
+		loaded params.SetManager
+	}{
+		{
+			name:   "shape mismatch",
+			model:  models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+			loaded: models.NewTwoLayerNet(4, 5, 2, matutil.NewRand(1)),
+		},
+		{
+			name:   "number of arrays mismatch",
+			model:  models.NewTwoLayerNet(4, 3, 2, matutil.NewRand(1)),
+			loaded: models.InitSimpleCBOW(4, 3, matutil.NewRand(1)),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := store.EncodeNpz(&buf, tt.model); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			before := mat.DenseCopyOf(tt.loaded.GetParams()[0].Weight)
+			r := bytes.NewReader(buf.Bytes())
+			if err := store.DecodeNpz(r, r.Size(), tt.loaded); err == nil {
+				t.Fatal("expected error")
+			}
+			if !mat.Equal(before, tt.loaded.GetParams()[0].Weight) {
+				t.Error("params are changed by failed load")
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package store
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/po3rin/gonnp/params"
+)
+
+// SaveNpz saves params of model to file in NumPy .npz format.
+// see EncodeNpz for layout of arrays.
+func SaveNpz(fileName string, model params.SetManager) (err error) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to create file")
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = errors.Wrap(cerr, "gonnp: failed to close file")
+		}
+	}()
+	return EncodeNpz(f, model)
+}
+
+// EncodeNpz writes params of model to w in NumPy .npz format.
+// Weight, WeightH & Bias of each param are written in order as arr_0, arr_1 ... like np.savez(*params)
+// with skipping empty fields. shared params are written once. Bias is written as 1-D array.
+func EncodeNpz(w io.Writer, model params.SetManager) error {
+	var arrays []npyArray
+	for _, p := range NewModelData(model).Params {
+		if p.Weight != nil {
+			arrays = append(arrays, npyArray{shape: []int{p.Weight.Rows, p.Weight.Cols}, data: p.Weight.Data})
+		}
+		if p.WeightH != nil {
+			arrays = append(arrays, npyArray{shape: []int{p.WeightH.Rows, p.WeightH.Cols}, data: p.WeightH.Data})
+		}
+		if p.Bias != nil {
+			arrays = append(arrays, npyArray{shape: []int{p.Bias.Rows}, data: p.Bias.Data})
+		}
+	}
+
+	zw := zip.NewWriter(w)
+	for i, a := range arrays {
+		f, err := zw.Create(fmt.Sprintf("arr_%d.npy", i))
+		if err == nil {
+			err = writeNpy(f, a)
+		}
+		if err != nil {
+			zw.Close()
+			return errors.Wrap(err, "gonnp: failed to encode npz")
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return errors.Wrap(err, "gonnp: failed to encode npz")
+	}
+	return nil
+}
+
+// LoadNpz loads params from NumPy .npz file into model.
+// see DecodeNpz for layout of arrays.
+func LoadNpz(fileName string, model params.SetManager) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to open file")
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to stat file")
+	}
+	return DecodeNpz(f, info.Size(), model)
+}
+
+// DecodeNpz reads params in NumPy .npz format from r into model.
+// arrays are assigned to Weight, WeightH & Bias of each param in order of entries in archive,
+// so file made by np.savez(*params) can be loaded. Bias accepts 1-D array or (n, 1) array.
+// number & shapes of arrays should be same as model.
+func DecodeNpz(r io.ReaderAt, size int64, model params.SetManager) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to decode npz")
+	}
+
+	arrays := make([]npyArray, 0, len(zr.File))
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".npy") {
+			continue
+		}
+		a, err := readNpzEntry(f)
+		if err != nil {
+			return errors.Wrapf(err, "gonnp: failed to decode %v", f.Name)
+		}
+		arrays = append(arrays, a)
+	}
+
+	ps := params.Unique(model.GetParams())
+	pds := make([]ParamData, len(ps))
+
+	var i int
+	next := func(exists, vector bool) (*Tensor, error) {
+		if !exists {
+			return nil, nil
+		}
+		if i >= len(arrays) {
+			return nil, errors.Errorf("gonnp: npz has only %v arrays", len(arrays))
+		}
+		a := arrays[i]
+		i++
+
+		switch {
+		case len(a.shape) == 1 && vector:
+			return &Tensor{Rows: a.shape[0], Cols: 1, Data: a.data}, nil
+		case len(a.shape) == 2:
+			return &Tensor{Rows: a.shape[0], Cols: a.shape[1], Data: a.data}, nil
+		}
+		return nil, errors.Errorf("gonnp: arr_%v has unexpected shape %v", i-1, a.shape)
+	}
+
+	for j, p := range ps {
+		if pds[j].Weight, err = next(p.Weight != nil, false); err != nil {
+			return err
+		}
+		if pds[j].WeightH, err = next(p.WeightH != nil, false); err != nil {
+			return err
+		}
+		if pds[j].Bias, err = next(p.Bias != nil, true); err != nil {
+			return err
+		}
+	}
+	if i != len(arrays) {
+		return errors.Errorf("gonnp: number of arrays mismatch: file has %v, but model has %v", len(arrays), i)
+	}
+
+	return restoreParams(model, pds)
+}
+
+func readNpzEntry(f *zip.File) (npyArray, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return npyArray{}, err
+	}
+	defer rc.Close()
+	return readNpy(rc)
+}
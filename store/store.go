@@ -3,8 +3,7 @@ package store
 
 import (
 	"encoding/gob"
-	"log"
-	"os"
+	"io"
 
 	"github.com/pkg/errors"
 	"gonum.org/v1/gonum/mat"
@@ -32,12 +31,17 @@ func NewCBOWEncoder(w2id map[string]float64, id2w map[float64]string, wordVecs m
 }
 
 // Encode CBOW output to file.
-func (c *CBOW) Encode(fileName string) error {
-	f, err := os.Create(fileName)
+func (c *CBOW) Encode(fileName string, opts ...OptionFunc) error {
+	err := encodeFile(fileName, &c, opts...)
 	if err != nil {
-		log.Fatal(err)
+		return errors.Wrap(err, "gonnp: failed to encode CBOWOutput struct")
 	}
-	err = gob.NewEncoder(f).Encode(&c)
+	return nil
+}
+
+// EncodeTo encodes CBOW output to w.
+func (c *CBOW) EncodeTo(w io.Writer, opts ...OptionFunc) error {
+	err := encode(w, &c, opts...)
 	if err != nil {
 		return errors.Wrap(err, "gonnp: failed to encode CBOWOutput struct")
 	}
@@ -46,12 +50,16 @@ func (c *CBOW) Encode(fileName string) error {
 
 // Decode CBOW output file to struct.
 func (c *CBOW) Decode(fileName string) error {
-	f, err := os.Open(fileName)
+	err := decodeFile(fileName, c)
 	if err != nil {
-		log.Fatal(err)
+		return errors.Wrap(err, "gonnp: failed to dencode file to CBOWOutput struct")
 	}
+	return nil
+}
 
-	err = gob.NewDecoder(f).Decode(c)
+// DecodeFrom decodes CBOW output from r. gzip compressed data is also supported.
+func (c *CBOW) DecodeFrom(r io.Reader) error {
+	err := decode(r, c)
 	if err != nil {
 		return errors.Wrap(err, "gonnp: failed to dencode file to CBOWOutput struct")
 	}
@@ -0,0 +1,135 @@
+// +build !e2e
+
+package store_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/store"
+	"gonum.org/v1/gonum/mat"
+)
+
+func testCBOW() *store.CBOW {
+	return store.NewCBOWEncoder(
+		map[string]float64{"you": 0, "say": 1, "hello": 2},
+		map[float64]string{0: "you", 1: "say", 2: "hello"},
+		mat.NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6}),
+	)
+}
+
+func TestCBOWEncodeTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []store.OptionFunc
+		wantGzip bool
+	}{
+		{
+			name: "plain",
+		},
+		{
+			name:     "gzip",
+			opts:     []store.OptionFunc{store.Gzip()},
+			wantGzip: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			want := testCBOW()
+
+			var buf bytes.Buffer
+			if err := want.EncodeTo(&buf, tt.opts...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gz := bytes.HasPrefix(buf.Bytes(), []byte{0x1f, 0x8b}); gz != tt.wantGzip {
+				t.Errorf("want gzip = %v, got = %v", tt.wantGzip, gz)
+			}
+
+			got := &store.CBOW{}
+			if err := got.DecodeFrom(&buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.W2ID, want.W2ID) {
+				t.Errorf("want = %v, got = %v", want.W2ID, got.W2ID)
+			}
+			if !reflect.DeepEqual(got.ID2W, want.ID2W) {
+				t.Errorf("want = %v, got = %v", want.ID2W, got.ID2W)
+			}
+			if !mat.Equal(got.WordVecs, want.WordVecs) {
+				t.Errorf("want = %v, got = %v", want.WordVecs, got.WordVecs)
+			}
+		})
+	}
+}
+
+func TestCBOWEncode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"cbow.gob", "cbow.gob.gz"} {
+		fileName := filepath.Join(dir, name)
+		want := testCBOW()
+		if err := want.Encode(fileName); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := &store.CBOW{}
+		if err := got.Decode(fileName); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !mat.Equal(got.WordVecs, want.WordVecs) {
+			t.Errorf("%v: want = %v, got = %v", name, want.WordVecs, got.WordVecs)
+		}
+	}
+}
+
+func TestCBOWDecodeError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := &store.CBOW{}
+	if err := c.Decode(filepath.Join(dir, "not_found.gob")); err == nil {
+		t.Error("expected error")
+	}
+	if err := c.DecodeFrom(bytes.NewBufferString("broken")); err == nil {
+		t.Error("expected error")
+	}
+	if err := testCBOW().Encode(filepath.Join(dir, "not_found", "cbow.gob")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestEncodeModelTo(t *testing.T) {
+	model := models.InitRNNLM(5, 3, 4, matutil.NewRand(1))
+	loaded := models.InitRNNLM(5, 3, 4, matutil.NewRand(1))
+
+	var buf bytes.Buffer
+	if err := store.EncodeModelTo(&buf, model, store.Gzip()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.DecodeModelFrom(&buf, loaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := model.GetParams()
+	got := loaded.GetParams()
+	for i := range want {
+		if !mat.Equal(want[i].Weight, got[i].Weight) {
+			t.Errorf("weight %v: want = %v, got = %v", i, want[i].Weight, got[i].Weight)
+		}
+	}
+}
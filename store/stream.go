@@ -0,0 +1,89 @@
+package store
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/gob"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type options struct {
+	gzip bool
+}
+
+// OptionFunc sets option of encoding.
+type OptionFunc func(o *options)
+
+// Gzip compresses output with gzip. file name which has ".gz" suffix is always compressed.
+func Gzip() OptionFunc {
+	return func(o *options) {
+		o.gzip = true
+	}
+}
+
+// encode encodes v into w using gob.
+func encode(w io.Writer, v interface{}, opts ...OptionFunc) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !o.gzip {
+		return gob.NewEncoder(w).Encode(v)
+	}
+
+	zw := gzip.NewWriter(w)
+	if err := gob.NewEncoder(zw).Encode(v); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// decode decodes gob data from r into v. gzip compressed data is detected by magic number.
+func decode(r io.Reader, v interface{}) error {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		return gob.NewDecoder(zr).Decode(v)
+	}
+	return gob.NewDecoder(br).Decode(v)
+}
+
+// encodeFile encodes v into file.
+func encodeFile(fileName string, v interface{}, opts ...OptionFunc) (err error) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to create file")
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = errors.Wrap(cerr, "gonnp: failed to close file")
+		}
+	}()
+
+	if strings.HasSuffix(fileName, ".gz") {
+		opts = append(opts, Gzip())
+	}
+	return encode(f, v, opts...)
+}
+
+// decodeFile decodes file into v.
+func decodeFile(fileName string, v interface{}) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to open file")
+	}
+	defer f.Close()
+
+	return decode(f, v)
+}
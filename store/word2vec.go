@@ -0,0 +1,201 @@
+package store
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// EncodeWord2Vec writes word vectors to w in word2vec text format.
+// first line is "<vocab size> <vector size>" & each line is word followed by its vector.
+func (c *CBOW) EncodeWord2Vec(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	err := c.writeWord2Vec(bw, func(v []float64) error {
+		for _, f := range v {
+			if _, err := bw.WriteString(" " + strconv.FormatFloat(f, 'f', -1, 32)); err != nil {
+				return err
+			}
+		}
+		return bw.WriteByte('\n')
+	})
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to encode word2vec text format")
+	}
+	return nil
+}
+
+// EncodeWord2VecBinary writes word vectors to w in original word2vec binary format.
+// vectors are written as little endian float32.
+func (c *CBOW) EncodeWord2VecBinary(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	err := c.writeWord2Vec(bw, func(v []float64) error {
+		buf := make([]float32, len(v))
+		for i, f := range v {
+			buf[i] = float32(f)
+		}
+		if err := bw.WriteByte(' '); err != nil {
+			return err
+		}
+		if err := binary.Write(bw, binary.LittleEndian, buf); err != nil {
+			return err
+		}
+		return bw.WriteByte('\n')
+	})
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to encode word2vec binary format")
+	}
+	return nil
+}
+
+// writeWord2Vec writes header & each word in order of id. vector is written by writeVec.
+func (c *CBOW) writeWord2Vec(bw *bufio.Writer, writeVec func(v []float64) error) error {
+	if c.WordVecs == nil {
+		return errors.New("gonnp: word vectors are empty")
+	}
+	r, col := c.WordVecs.Dims()
+	if _, err := fmt.Fprintf(bw, "%d %d\n", r, col); err != nil {
+		return err
+	}
+
+	for i := 0; i < r; i++ {
+		w, ok := c.ID2W[float64(i)]
+		if !ok {
+			return errors.Errorf("gonnp: word of id %v is not found", i)
+		}
+		if strings.ContainsAny(w, " \n") {
+			return errors.Errorf("gonnp: word %q contains white space", w)
+		}
+		if _, err := bw.WriteString(w); err != nil {
+			return err
+		}
+		if err := writeVec(mat.Row(nil, i, c.WordVecs)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// DecodeWord2Vec reads word vectors in word2vec text format from r.
+// ids are assigned in order of appearance.
+func (c *CBOW) DecodeWord2Vec(r io.Reader) error {
+	err := c.readWord2Vec(r, func(br *bufio.Reader, d int) (string, []float64, error) {
+		line, err := br.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", nil, err
+		}
+		fields := strings.Fields(line)
+		if len(fields) != d+1 {
+			return "", nil, errors.Errorf("gonnp: line has %v fields, want %v", len(fields), d+1)
+		}
+		v := make([]float64, d)
+		for i, f := range fields[1:] {
+			v[i], err = strconv.ParseFloat(f, 64)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		return fields[0], v, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to decode word2vec text format")
+	}
+	return nil
+}
+
+// DecodeWord2VecBinary reads word vectors in original word2vec binary format from r.
+// ids are assigned in order of appearance.
+func (c *CBOW) DecodeWord2VecBinary(r io.Reader) error {
+	err := c.readWord2Vec(r, func(br *bufio.Reader, d int) (string, []float64, error) {
+		w, err := br.ReadString(' ')
+		if err != nil {
+			return "", nil, err
+		}
+		w = strings.TrimLeft(strings.TrimSuffix(w, " "), "\n")
+
+		buf := make([]float32, d)
+		if err := binary.Read(br, binary.LittleEndian, buf); err != nil {
+			return "", nil, err
+		}
+		v := make([]float64, d)
+		for i, f := range buf {
+			v[i] = float64(f)
+		}
+		return w, v, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to decode word2vec binary format")
+	}
+	return nil
+}
+
+// readWord2Vec reads header & each word by readWord, then sets W2ID, ID2W & WordVecs.
+func (c *CBOW) readWord2Vec(r io.Reader, readWord func(br *bufio.Reader, d int) (string, []float64, error)) error {
+	br := bufio.NewReader(r)
+	header, err := br.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	var n, d int
+	if _, err := fmt.Sscan(header, &n, &d); err != nil {
+		return errors.Wrap(err, "gonnp: invalid header")
+	}
+	if n <= 0 || d <= 0 {
+		return errors.Errorf("gonnp: invalid header %q", strings.TrimSpace(header))
+	}
+
+	w2id := make(map[string]float64, n)
+	id2w := make(map[float64]string, n)
+	vecs := mat.NewDense(n, d, nil)
+	for i := 0; i < n; i++ {
+		w, v, err := readWord(br, d)
+		if err != nil {
+			return errors.Wrapf(err, "gonnp: failed to read %v-th word", i)
+		}
+		if _, ok := w2id[w]; ok {
+			return errors.Errorf("gonnp: word %q is duplicated", w)
+		}
+		w2id[w] = float64(i)
+		id2w[float64(i)] = w
+		vecs.SetRow(i, v)
+	}
+
+	c.W2ID = w2id
+	c.ID2W = id2w
+	c.WordVecs = vecs
+	return nil
+}
+
+// SeedEmbedding copies vectors of words which are found in w2id into rows of weight.
+// weight is (vocab size of w2id, vector size) matrix like weight of InitEmbeddingLayer.
+// rows of unknown words are kept as it is. it returns number of copied words.
+func (c *CBOW) SeedEmbedding(weight *mat.Dense, w2id map[string]float64) (int, error) {
+	if c.WordVecs == nil {
+		return 0, errors.New("gonnp: word vectors are empty")
+	}
+	r, col := weight.Dims()
+	_, d := c.WordVecs.Dims()
+	if col != d {
+		return 0, errors.Errorf("gonnp: vector size is %v, but weight has %v columns", d, col)
+	}
+
+	var count int
+	for w, id := range w2id {
+		src, ok := c.W2ID[w]
+		if !ok {
+			continue
+		}
+		if id < 0 || int(id) >= r || id != math.Trunc(id) {
+			return count, errors.Errorf("gonnp: id %v of word %q is out of weight", id, w)
+		}
+		weight.SetRow(int(id), mat.Row(nil, int(src), c.WordVecs))
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,146 @@
+// +build !e2e
+
+package store_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/po3rin/gonnp/store"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWord2Vec(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func(c *store.CBOW, b *bytes.Buffer) error
+		decode func(c *store.CBOW, b *bytes.Buffer) error
+	}{
+		{
+			name:   "text",
+			encode: func(c *store.CBOW, b *bytes.Buffer) error { return c.EncodeWord2Vec(b) },
+			decode: func(c *store.CBOW, b *bytes.Buffer) error { return c.DecodeWord2Vec(b) },
+		},
+		{
+			name:   "binary",
+			encode: func(c *store.CBOW, b *bytes.Buffer) error { return c.EncodeWord2VecBinary(b) },
+			decode: func(c *store.CBOW, b *bytes.Buffer) error { return c.DecodeWord2VecBinary(b) },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			want := store.NewCBOWEncoder(
+				map[string]float64{"you": 0, "say": 1, "hello": 2},
+				map[float64]string{0: "you", 1: "say", 2: "hello"},
+				mat.NewDense(3, 2, []float64{0.1, -2, 3.5, 4, 0.25, -6}),
+			)
+
+			var buf bytes.Buffer
+			if err := tt.encode(want, &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := &store.CBOW{}
+			if err := tt.decode(got, &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.W2ID, want.W2ID) {
+				t.Errorf("want = %v, got = %v", want.W2ID, got.W2ID)
+			}
+			if !reflect.DeepEqual(got.ID2W, want.ID2W) {
+				t.Errorf("want = %v, got = %v", want.ID2W, got.ID2W)
+			}
+			if !mat.EqualApprox(got.WordVecs, want.WordVecs, 1e-6) {
+				t.Errorf("want = %v, got = %v", want.WordVecs, got.WordVecs)
+			}
+		})
+	}
+}
+
+func TestDecodeWord2Vec(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *mat.Dense
+		wantErr bool
+	}{
+		{
+			name:  "simple",
+			input: "2 3\nyou 1 2 3\nsay 0.5 -1 1e-2\n",
+			want:  mat.NewDense(2, 3, []float64{1, 2, 3, 0.5, -1, 0.01}),
+		},
+		{
+			name:  "trailing space & no last new line",
+			input: "1 2\nyou 1 2 \n",
+			want:  mat.NewDense(1, 2, []float64{1, 2}),
+		},
+		{
+			name:    "dimension mismatch",
+			input:   "1 3\nyou 1 2\n",
+			wantErr: true,
+		},
+		{
+			name:    "too few words",
+			input:   "2 2\nyou 1 2\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicated word",
+			input:   "2 1\nyou 1\nyou 2\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid header",
+			input:   "you 1 2\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := &store.CBOW{}
+			err := c.DecodeWord2Vec(bytes.NewBufferString(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !mat.Equal(c.WordVecs, tt.want) {
+				t.Errorf("want = %v, got = %v", tt.want, c.WordVecs)
+			}
+		})
+	}
+}
+
+func TestSeedEmbedding(t *testing.T) {
+	c := &store.CBOW{}
+	err := c.DecodeWord2Vec(bytes.NewBufferString("2 2\nyou 1 2\nhello 3 4\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	weight := mat.NewDense(3, 2, []float64{9, 9, 9, 9, 9, 9})
+	n, err := c.SeedEmbedding(weight, map[string]float64{"hello": 0, "say": 1, "you": 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("want = 2, got = %v", n)
+	}
+	want := mat.NewDense(3, 2, []float64{3, 4, 9, 9, 1, 2})
+	if !mat.Equal(weight, want) {
+		t.Errorf("want = %v, got = %v", want, weight)
+	}
+
+	if _, err := c.SeedEmbedding(mat.NewDense(3, 3, nil), map[string]float64{"you": 0}); err == nil {
+		t.Error("expected error")
+	}
+}
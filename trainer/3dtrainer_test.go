@@ -178,7 +178,7 @@ func Test3DFit(t *testing.T) {
 	te := word.ConvertOneHot(target, vocabSize)
 	co := word.ConvertOneHot(contexts, vocabSize)
 
-	model := models.InitSimpleCBOW(vocabSize, hiddenSize)
+	model := models.InitSimpleCBOW(vocabSize, hiddenSize, matutil.NewRand(1))
 	optimizer := optimizers.InitAdam(0.001, 0.9, 0.999)
 	trainer := trainer.InitTrainer(model, optimizer)
 
@@ -0,0 +1,16 @@
+package trainer
+
+// Shuffle sets whether data is shuffled every epoch. default is true.
+func Shuffle(b bool) func(*Train) {
+	return func(t *Train) {
+		t.Shuffle = b
+	}
+}
+
+// KeepPartialBatch sets whether the last mini-batch smaller than batch size is used.
+// default is false, so remainder rows are dropped every epoch.
+func KeepPartialBatch(b bool) func(*Train) {
+	return func(t *Train) {
+		t.KeepPartialBatch = b
+	}
+}
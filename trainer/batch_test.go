@@ -0,0 +1,150 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// batchModel records first column of each mini-batch.
+type batchModel struct {
+	constModel
+	batches [][]float64
+}
+
+func (b *batchModel) Forward(teacher mat.Matrix, x ...mat.Matrix) float64 {
+	if len(x) == 1 {
+		b.batches = append(b.batches, mat.Col(nil, 0, x[0]))
+	} else {
+		// 3D input. records first value of each sample.
+		batch := make([]float64, len(x))
+		for i, m := range x {
+			batch[i] = m.At(0, 0)
+		}
+		b.batches = append(b.batches, batch)
+	}
+	return b.constModel.Forward(teacher, x...)
+}
+
+func TestFitBatchOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []trainer.OptionFunc
+		want    [][]float64
+	}{
+		{
+			name:    "drop partial batch",
+			options: []trainer.OptionFunc{trainer.Shuffle(false)},
+			want:    [][]float64{{0, 1}, {2, 3}},
+		},
+		{
+			name:    "keep partial batch",
+			options: []trainer.OptionFunc{trainer.Shuffle(false), trainer.KeepPartialBatch(true)},
+			want:    [][]float64{{0, 1}, {2, 3}, {4}},
+		},
+	}
+
+	x := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+	teacher := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+	x3D := []mat.Matrix{
+		mat.NewDense(1, 1, []float64{0}),
+		mat.NewDense(1, 1, []float64{1}),
+		mat.NewDense(1, 1, []float64{2}),
+		mat.NewDense(1, 1, []float64{3}),
+		mat.NewDense(1, 1, []float64{4}),
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]trainer.OptionFunc{trainer.Logger(nil)}, tt.options...)
+
+			m := &batchModel{}
+			trainer.InitTrainer(m, optimizers.InitSDG(0.1), options...).Fit(x, teacher, 1, 2)
+			if !reflect.DeepEqual(m.batches, tt.want) {
+				t.Errorf("Fit: want = %v, got = %v", tt.want, m.batches)
+			}
+
+			m = &batchModel{}
+			trainer.InitTrainer(m, optimizers.InitSDG(0.1), options...).Fit3D(x3D, teacher, 1, 2)
+			if !reflect.DeepEqual(m.batches, tt.want) {
+				t.Errorf("Fit3D: want = %v, got = %v", tt.want, m.batches)
+			}
+		})
+	}
+}
+
+func TestFitShuffleWithPartialBatch(t *testing.T) {
+	x := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+	teacher := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+
+	m := &batchModel{}
+	tr := trainer.InitTrainer(m, optimizers.InitSDG(0.1), trainer.Logger(nil), trainer.KeepPartialBatch(true))
+	tr.Fit(x, teacher, 2, 2)
+
+	if len(m.batches) != 6 {
+		t.Fatalf("want = 6, got = %v", len(m.batches))
+	}
+	for epoch := 0; epoch < 2; epoch++ {
+		var rows []float64
+		for _, b := range m.batches[epoch*3 : (epoch+1)*3] {
+			rows = append(rows, b...)
+		}
+		sort.Float64s(rows)
+		if want := []float64{0, 1, 2, 3, 4}; !reflect.DeepEqual(rows, want) {
+			t.Errorf("epoch %v: want = %v, got = %v", epoch, want, rows)
+		}
+	}
+}
+
+// meanModel returns mean of first value of samples in mini-batch as loss.
+type meanModel struct {
+	constModel
+}
+
+func (m *meanModel) Forward(teacher mat.Matrix, x ...mat.Matrix) float64 {
+	if len(x) == 1 {
+		col := mat.Col(nil, 0, x[0])
+		return floats.Sum(col) / float64(len(col))
+	}
+	var sum float64
+	for _, v := range x {
+		sum += v.At(0, 0)
+	}
+	return sum / float64(len(x))
+}
+
+func TestFitEpochLossWithPartialBatch(t *testing.T) {
+	x := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+	teacher := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+	x3D := []mat.Matrix{
+		mat.NewDense(1, 1, []float64{0}),
+		mat.NewDense(1, 1, []float64{1}),
+		mat.NewDense(1, 1, []float64{2}),
+		mat.NewDense(1, 1, []float64{3}),
+		mat.NewDense(1, 1, []float64{4}),
+	}
+
+	// losses of mini-batches are 0.5, 2.5 & 4. weighted by rows, epoch loss is mean of all rows.
+	want := 2.0
+	options := []trainer.OptionFunc{trainer.Logger(nil), trainer.KeepPartialBatch(true), trainer.Shuffle(false)}
+
+	tr := trainer.InitTrainer(&meanModel{}, optimizers.InitSDG(0.1), options...)
+	tr.Fit(x, teacher, 1, 2)
+	if got := tr.History.Loss[0]; got != want {
+		t.Errorf("Fit: want = %v, got = %v", want, got)
+	}
+
+	tr = trainer.InitTrainer(&meanModel{}, optimizers.InitSDG(0.1), options...)
+	tr.Fit3D(x3D, teacher, 1, 2)
+	if got := tr.History.Loss[0]; got != want {
+		t.Errorf("Fit3D: want = %v, got = %v", want, got)
+	}
+}
@@ -0,0 +1,270 @@
+package trainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/po3rin/gonnp/params"
+)
+
+// Event is state of training passed to Callback. Epoch & Iter are 0-origin.
+type Event struct {
+	Epoch    int
+	Iter     int
+	MaxIters int
+	// Loss is loss of the mini-batch on OnBatchEnd & average loss of the epoch on OnEpochEnd.
+	Loss float64
+	// Eval reports whether iteration is on EvalInterval. AvgLoss is average loss since last evaluation
+	// & Perplexity is exp of it which is set by FitSequence only.
+	Eval       bool
+	AvgLoss    float64
+	Perplexity float64
+	// Valid is result of Validator on OnEpochEnd. HasValid reports whether Validator is set.
+	Valid    float64
+	HasValid bool
+	// Metrics is results of metrics on validation data on OnEpochEnd.
+	Metrics map[string]float64
+
+	model params.SetManager
+}
+
+// NewEvent inits Event of model. params of model are got on calling Params only.
+func NewEvent(epoch, iter, maxIters int, model params.SetManager) Event {
+	return Event{Epoch: epoch, Iter: iter, MaxIters: maxIters, model: model}
+}
+
+// Params returns params of the model. params are not copied.
+func (e Event) Params() []params.Param {
+	if e.model == nil {
+		return nil
+	}
+	return e.model.GetParams()
+}
+
+// Callback is called on events of training.
+type Callback interface {
+	OnEpochBegin(e Event)
+	OnBatchEnd(e Event)
+	OnEpochEnd(e Event)
+}
+
+// ErrorCallback is Callback which is notified of errors which do not stop training
+// like failure of saving checkpoint.
+type ErrorCallback interface {
+	OnError(e Event, err error)
+}
+
+// Logger sets logger of training. default logger is TextLogger which writes to stdout.
+func Logger(l Callback) func(*Train) {
+	return func(t *Train) {
+		t.Logger = l
+	}
+}
+
+// Callbacks adds callbacks which are called after logger.
+func Callbacks(cs ...Callback) func(*Train) {
+	return func(t *Train) {
+		t.Callbacks = append(t.Callbacks, cs...)
+	}
+}
+
+// SilentLogger logs nothing.
+type SilentLogger struct{}
+
+// OnEpochBegin does nothing.
+func (SilentLogger) OnEpochBegin(e Event) {}
+
+// OnBatchEnd does nothing.
+func (SilentLogger) OnBatchEnd(e Event) {}
+
+// OnEpochEnd does nothing.
+func (SilentLogger) OnEpochEnd(e Event) {}
+
+// TextLogger logs loss on EvalInterval & validation result as text.
+type TextLogger struct {
+	W io.Writer
+}
+
+// NewTextLogger inits TextLogger.
+func NewTextLogger(w io.Writer) *TextLogger {
+	return &TextLogger{W: w}
+}
+
+// OnEpochBegin does nothing.
+func (l *TextLogger) OnEpochBegin(e Event) {}
+
+// OnBatchEnd logs average loss or perplexity.
+func (l *TextLogger) OnBatchEnd(e Event) {
+	if !e.Eval {
+		return
+	}
+	if e.Perplexity > 0 {
+		fmt.Fprintf(l.W, "| epoch %v |  iter %v / %v | perplexity %.2f\n", e.Epoch, e.Iter, e.MaxIters, e.Perplexity)
+		return
+	}
+	fmt.Fprintf(l.W, "| epoch %v |  iter %v / %v | loss %.4f\n", e.Epoch, e.Iter, e.MaxIters, e.AvgLoss)
+}
+
+// OnEpochEnd logs validation result & metrics.
+func (l *TextLogger) OnEpochEnd(e Event) {
+	if !e.HasValid && len(e.Metrics) == 0 {
+		return
+	}
+	items := []string{fmt.Sprintf("epoch %v", e.Epoch)}
+	if e.HasValid {
+		items = append(items, fmt.Sprintf("valid %.4f", e.Valid))
+	}
+	for _, name := range metricNames(e.Metrics) {
+		items = append(items, fmt.Sprintf("%v %.4f", name, e.Metrics[name]))
+	}
+	fmt.Fprintf(l.W, "| %v\n", strings.Join(items, " | "))
+}
+
+// OnError logs error.
+func (l *TextLogger) OnError(e Event, err error) {
+	fmt.Fprintf(l.W, "| epoch %v | %v\n", e.Epoch, err)
+}
+
+// JSONLogger logs events as JSON lines. batch is logged on EvalInterval only.
+// NaN & infinity are logged as strings "NaN", "+Inf" & "-Inf". first error of encoding is kept in Err.
+type JSONLogger struct {
+	Err error
+	enc *json.Encoder
+}
+
+type jsonLog struct {
+	Event      string               `json:"event"`
+	Epoch      int                  `json:"epoch"`
+	Iter       *int                 `json:"iter,omitempty"`
+	MaxIters   int                  `json:"max_iters"`
+	Loss       *jsonFloat           `json:"loss,omitempty"`
+	Perplexity *jsonFloat           `json:"perplexity,omitempty"`
+	Valid      *jsonFloat           `json:"valid,omitempty"`
+	Metrics    map[string]jsonFloat `json:"metrics,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// jsonFloat is float64 which is encoded as string if it is not finite since JSON has no number for it.
+type jsonFloat float64
+
+func newJSONFloat(v float64) *jsonFloat {
+	f := jsonFloat(v)
+	return &f
+}
+
+// MarshalJSON encodes NaN & infinity as "NaN", "+Inf" & "-Inf".
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return json.Marshal(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return json.Marshal(v)
+}
+
+// NewJSONLogger inits JSONLogger.
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{enc: json.NewEncoder(w)}
+}
+
+// OnEpochBegin logs beginning of epoch.
+func (l *JSONLogger) OnEpochBegin(e Event) {
+	l.write(jsonLog{Event: "epoch_begin", Epoch: e.Epoch, MaxIters: e.MaxIters})
+}
+
+// OnBatchEnd logs average loss.
+func (l *JSONLogger) OnBatchEnd(e Event) {
+	if !e.Eval {
+		return
+	}
+	j := jsonLog{Event: "batch_end", Epoch: e.Epoch, Iter: &e.Iter, MaxIters: e.MaxIters, Loss: newJSONFloat(e.AvgLoss)}
+	if e.Perplexity > 0 {
+		j.Perplexity = newJSONFloat(e.Perplexity)
+	}
+	l.write(j)
+}
+
+// OnEpochEnd logs average loss of epoch & validation result.
+func (l *JSONLogger) OnEpochEnd(e Event) {
+	j := jsonLog{Event: "epoch_end", Epoch: e.Epoch, MaxIters: e.MaxIters, Loss: newJSONFloat(e.Loss)}
+	if e.HasValid {
+		j.Valid = newJSONFloat(e.Valid)
+	}
+	if len(e.Metrics) > 0 {
+		j.Metrics = make(map[string]jsonFloat, len(e.Metrics))
+		for name, v := range e.Metrics {
+			j.Metrics[name] = jsonFloat(v)
+		}
+	}
+	l.write(j)
+}
+
+// OnError logs error.
+func (l *JSONLogger) OnError(e Event, err error) {
+	l.write(jsonLog{Event: "error", Epoch: e.Epoch, MaxIters: e.MaxIters, Error: err.Error()})
+}
+
+func (l *JSONLogger) write(j jsonLog) {
+	if err := l.enc.Encode(j); err != nil && l.Err == nil {
+		l.Err = err
+	}
+}
+
+// metricNames returns names of metrics in sorted order.
+func metricNames(m map[string]float64) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// callbacks returns logger & callbacks in order of calling.
+func (t *Train) callbacks() []Callback {
+	if t.Logger == nil {
+		return t.Callbacks
+	}
+	return append([]Callback{t.Logger}, t.Callbacks...)
+}
+
+// event creates Event of current state.
+func (t *Train) event(iter, maxIters int) Event {
+	return NewEvent(int(t.CurrentEpoch), iter, maxIters, t.Model)
+}
+
+// beginEpoch calls OnEpochBegin of callbacks.
+func (t *Train) beginEpoch(maxIters int) {
+	e := t.event(0, maxIters)
+	for _, c := range t.callbacks() {
+		c.OnEpochBegin(e)
+	}
+}
+
+// endBatch records average loss on EvalInterval & calls OnBatchEnd of callbacks.
+func (t *Train) endBatch(e Event) {
+	if e.Eval {
+		if e.Perplexity > 0 {
+			t.PplList = append(t.PplList, e.Perplexity)
+		} else {
+			t.LossList = append(t.LossList, e.AvgLoss)
+		}
+		t.recordLR()
+	}
+	for _, c := range t.callbacks() {
+		c.OnBatchEnd(e)
+	}
+}
+
+// reportError calls OnError of callbacks which implement ErrorCallback.
+func (t *Train) reportError(e Event, err error) {
+	for _, c := range t.callbacks() {
+		if ec, ok := c.(ErrorCallback); ok {
+			ec.OnError(e, err)
+		}
+	}
+}
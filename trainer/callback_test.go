@@ -0,0 +1,175 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"github.com/po3rin/gonnp/word"
+)
+
+// recorder records names of events.
+type recorder struct {
+	events []string
+	params int
+}
+
+func (r *recorder) OnEpochBegin(e trainer.Event) {
+	r.events = append(r.events, fmt.Sprintf("begin %v", e.Epoch))
+}
+
+func (r *recorder) OnBatchEnd(e trainer.Event) {
+	r.events = append(r.events, fmt.Sprintf("batch %v %v %v", e.Epoch, e.Iter, e.Eval))
+	r.params = len(e.Params())
+}
+
+func (r *recorder) OnEpochEnd(e trainer.Event) {
+	r.events = append(r.events, fmt.Sprintf("end %v %v", e.Epoch, e.HasValid))
+}
+
+func TestCallbacks(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	r := &recorder{}
+	tr := trainer.InitTrainer(
+		model, optimizers.InitSDG(0.1),
+		trainer.EvalInterval(2),
+		trainer.Logger(trainer.SilentLogger{}),
+		trainer.Callbacks(r),
+		trainer.Validation(func(m trainer.Model) float64 { return 1 }),
+	)
+
+	// 15 words makes 2 iterations per epoch.
+	tr.FitSequence(corpus, 2, 2, 3)
+
+	want := []string{
+		"begin 0", "batch 0 0 true", "batch 0 1 false", "end 0 true",
+		"begin 1", "batch 1 0 true", "batch 1 1 false", "end 1 true",
+	}
+	if strings.Join(r.events, ", ") != strings.Join(want, ", ") {
+		t.Errorf("want = %v, got = %v", want, r.events)
+	}
+	if r.params != len(model.GetParams()) {
+		t.Errorf("want = %v, got = %v", len(model.GetParams()), r.params)
+	}
+}
+
+func TestFit3DCallbacks(t *testing.T) {
+	text := "You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+	contexts, target := word.CreateContextsAndTarget(corpus, 1)
+	te := word.ConvertOneHot(target, len(w2id))
+	co := word.ConvertOneHot(contexts, len(w2id))
+
+	r := &recorder{}
+	tr := trainer.InitTrainer(
+		models.InitSimpleCBOW(len(w2id), 3, matutil.NewRand(1)), optimizers.InitSDG(0.1),
+		trainer.Logger(nil),
+		trainer.Callbacks(r),
+	)
+	// 6 contexts makes 3 iterations per epoch.
+	tr.Fit3D(co, matutil.At3D(te, 0), 1, 2)
+
+	want := []string{"begin 0", "batch 0 0 true", "batch 0 1 false", "batch 0 2 false", "end 0 false"}
+	if strings.Join(r.events, ", ") != strings.Join(want, ", ") {
+		t.Errorf("want = %v, got = %v", want, r.events)
+	}
+}
+
+func TestTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := trainer.NewTextLogger(&buf)
+
+	l.OnEpochBegin(trainer.Event{Epoch: 0, MaxIters: 10})
+	l.OnBatchEnd(trainer.Event{Epoch: 0, Iter: 0, MaxIters: 10, Eval: true, AvgLoss: 1.23456})
+	l.OnBatchEnd(trainer.Event{Epoch: 0, Iter: 1, MaxIters: 10, Loss: 1})
+	l.OnBatchEnd(trainer.Event{Epoch: 0, Iter: 2, MaxIters: 10, Eval: true, Perplexity: 100.123})
+	l.OnEpochEnd(trainer.Event{Epoch: 0, MaxIters: 10})
+	l.OnEpochEnd(trainer.Event{Epoch: 1, MaxIters: 10, Valid: 3.5, HasValid: true})
+
+	want := `| epoch 0 |  iter 0 / 10 | loss 1.2346
+| epoch 0 |  iter 2 / 10 | perplexity 100.12
+| epoch 1 | valid 3.5000
+`
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%v\ngot :\n%v\n", want, got)
+	}
+}
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := trainer.NewJSONLogger(&buf)
+
+	l.OnEpochBegin(trainer.Event{Epoch: 0, MaxIters: 10})
+	l.OnBatchEnd(trainer.Event{Epoch: 0, Iter: 0, MaxIters: 10, Eval: true, AvgLoss: 1.5})
+	l.OnBatchEnd(trainer.Event{Epoch: 0, Iter: 1, MaxIters: 10, Loss: 1})
+	l.OnEpochEnd(trainer.Event{Epoch: 0, MaxIters: 10, Loss: 0.5, Valid: 2, HasValid: true})
+
+	want := []map[string]interface{}{
+		{"event": "epoch_begin", "epoch": 0.0, "max_iters": 10.0},
+		{"event": "batch_end", "epoch": 0.0, "iter": 0.0, "max_iters": 10.0, "loss": 1.5},
+		{"event": "epoch_end", "epoch": 0.0, "max_iters": 10.0, "loss": 0.5, "valid": 2.0},
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("want = %v lines, got = %v", len(want), lines)
+	}
+	for i, line := range lines {
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want[i]) {
+			t.Errorf("want = %v, got = %v", want[i], got)
+		}
+	}
+	if l.Err != nil {
+		t.Errorf("unexpected error: %v", l.Err)
+	}
+}
+
+func TestJSONLoggerNonFinite(t *testing.T) {
+	var buf bytes.Buffer
+	l := trainer.NewJSONLogger(&buf)
+
+	l.OnBatchEnd(trainer.Event{Epoch: 0, Iter: 0, MaxIters: 10, Eval: true, AvgLoss: math.NaN(), Perplexity: math.Inf(1)})
+	l.OnEpochEnd(trainer.Event{
+		Epoch: 0, MaxIters: 10, Loss: math.NaN(), Valid: math.Inf(-1), HasValid: true,
+		Metrics: map[string]float64{"accuracy": math.NaN()},
+	})
+
+	want := []map[string]interface{}{
+		{"event": "batch_end", "epoch": 0.0, "iter": 0.0, "max_iters": 10.0, "loss": "NaN", "perplexity": "+Inf"},
+		{"event": "epoch_end", "epoch": 0.0, "max_iters": 10.0, "loss": "NaN", "valid": "-Inf",
+			"metrics": map[string]interface{}{"accuracy": "NaN"}},
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("want = %v lines, got = %v", len(want), lines)
+	}
+	for i, line := range lines {
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want[i]) {
+			t.Errorf("want = %v, got = %v", want[i], got)
+		}
+	}
+	if l.Err != nil {
+		t.Errorf("unexpected error: %v", l.Err)
+	}
+}
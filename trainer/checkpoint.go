@@ -0,0 +1,161 @@
+package trainer
+
+import (
+	"encoding/gob"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/schedule"
+	"github.com/po3rin/gonnp/store"
+	"gonum.org/v1/gonum/mat"
+)
+
+func init() {
+	// regist mat.Matrix & mat.Vector data structure.
+	gob.Register(mat.Matrix(&mat.Dense{}))
+	gob.Register(mat.Vector(&mat.VecDense{}))
+}
+
+// Checkpoint is snapshot of training to resume.
+// InitLR is initial learning rate of Scheduler & SchedulerState is nil unless Scheduler has state.
+type Checkpoint struct {
+	Model          *store.ModelData
+	OptimizerState optimizers.State
+	InitLR         float64
+	SchedulerState *schedule.State
+	CurrentEpoch   float64
+	CurrentIter    int
+	LossList       []float64
+	LRList         []float64
+	PplList        []float64
+	ValidList      []float64
+	History        History
+}
+
+// CheckpointFile sets file name of checkpoint saved at the end of each epoch.
+func CheckpointFile(fileName string) func(*Train) {
+	return func(t *Train) {
+		t.CheckpointFile = fileName
+	}
+}
+
+// SaveCheckpoint saves params of model, state of optimizer & progress of training to file.
+// optimizer should implement optimizers.StateManager.
+func (t *Train) SaveCheckpoint(fileName string) error {
+	sm, ok := t.Optimizer.(optimizers.StateManager)
+	if !ok {
+		return errors.New("gonnp: optimizer does not support exporting state")
+	}
+
+	c := Checkpoint{
+		Model:          store.NewModelData(t.Model),
+		OptimizerState: sm.GetState(),
+		InitLR:         t.initLR,
+		CurrentEpoch:   t.CurrentEpoch,
+		CurrentIter:    t.CurrentIter,
+		LossList:       t.LossList,
+		LRList:         t.LRList,
+		PplList:        t.PplList,
+		ValidList:      t.ValidList,
+		History:        t.History,
+	}
+	if ssm, ok := t.Scheduler.(schedule.StateManager); ok {
+		st := ssm.GetState()
+		c.SchedulerState = &st
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to create checkpoint file")
+	}
+	defer f.Close()
+
+	if err := gob.NewEncoder(f).Encode(&c); err != nil {
+		return errors.Wrap(err, "gonnp: failed to encode checkpoint")
+	}
+	return nil
+}
+
+// LoadCheckpoint loads checkpoint file & restores model, optimizer, scheduler & progress of training.
+// type & shapes of params should be same as model. nothing is restored on error.
+// Fit runs maxEpoch epochs from restored CurrentEpoch, so pass remaining epochs to resume.
+func (t *Train) LoadCheckpoint(fileName string) error {
+	sm, ok := t.Optimizer.(optimizers.StateManager)
+	if !ok {
+		return errors.New("gonnp: optimizer does not support importing state")
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return errors.Wrap(err, "gonnp: failed to open checkpoint file")
+	}
+	defer f.Close()
+
+	var c Checkpoint
+	if err := gob.NewDecoder(f).Decode(&c); err != nil {
+		return errors.Wrap(err, "gonnp: failed to decode checkpoint")
+	}
+
+	ssm, ok := t.Scheduler.(schedule.StateManager)
+	if c.SchedulerState != nil && !ok {
+		return errors.New("gonnp: scheduler does not support importing state")
+	}
+	if c.Model == nil {
+		return errors.New("gonnp: checkpoint does not have model")
+	}
+	if err := c.Model.Restore(t.Model); err != nil {
+		return errors.Wrap(err, "gonnp: checkpoint does not match model")
+	}
+
+	sm.SetState(c.OptimizerState)
+	if c.SchedulerState != nil {
+		ssm.SetState(*c.SchedulerState)
+	}
+	t.initLR = c.InitLR
+	t.CurrentEpoch = c.CurrentEpoch
+	t.CurrentIter = c.CurrentIter
+	t.LossList = c.LossList
+	t.LRList = c.LRList
+	t.PplList = c.PplList
+	t.ValidList = c.ValidList
+	t.History = c.History
+	return nil
+}
+
+// saveCheckpoint saves checkpoint if CheckpointFile is set.
+// error is reported to callbacks which implement ErrorCallback.
+func (t *Train) saveCheckpoint(e Event) {
+	if t.CheckpointFile == "" {
+		return
+	}
+	if err := t.SaveCheckpoint(t.CheckpointFile); err != nil {
+		t.reportError(e, err)
+	}
+}
+
+// uniqueParams returns unique params copied for encoding.
+func uniqueParams(ps []params.Param) []params.Param {
+	ps = params.Unique(ps)
+	result := make([]params.Param, len(ps))
+	for i, p := range ps {
+		result[i] = denseParam(p)
+	}
+	return result
+}
+
+// denseParam copies param into *mat.Dense & *mat.VecDense for encoding.
+func denseParam(p params.Param) params.Param {
+	var d params.Param
+	if p.Weight != nil {
+		d.Weight = mat.DenseCopyOf(p.Weight)
+	}
+	if p.WeightH != nil {
+		d.WeightH = mat.DenseCopyOf(p.WeightH)
+	}
+	if p.Bias != nil {
+		d.Bias = mat.VecDenseCopyOf(p.Bias)
+	}
+	return d
+}
@@ -0,0 +1,256 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/schedule"
+	"github.com/po3rin/gonnp/trainer"
+	"github.com/po3rin/gonnp/word"
+	"gonum.org/v1/gonum/mat"
+)
+
+type nopOptimizer struct{}
+
+func (nopOptimizer) Update(ps []params.Param, grads []params.Grad) []params.Param {
+	return ps
+}
+
+func TestCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "checkpoint.gob")
+
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	optimizer := optimizers.InitAdam(0.01, 0.9, 0.999)
+	tr := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(1), trainer.CheckpointFile(fileName))
+	tr.FitSequence(corpus, 2, 2, 3)
+
+	resumedModel := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	resumedOptimizer := optimizers.InitAdam(0.01, 0.9, 0.999)
+	resumed := trainer.InitTrainer(resumedModel, resumedOptimizer)
+	if err := resumed.LoadCheckpoint(fileName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resumed.CurrentEpoch != 2 || resumed.CurrentIter != tr.CurrentIter {
+		t.Errorf("progress: want = (2, %v), got = (%v, %v)", tr.CurrentIter, resumed.CurrentEpoch, resumed.CurrentIter)
+	}
+	if len(resumed.PplList) != len(tr.PplList) {
+		t.Errorf("ppl list: want = %v, got = %v", tr.PplList, resumed.PplList)
+	}
+
+	want := model.GetParams()
+	got := resumedModel.GetParams()
+	for i := range want {
+		if !mat.Equal(want[i].Weight, got[i].Weight) {
+			t.Errorf("weight %v is not restored", i)
+		}
+		if want[i].Bias != nil && !mat.Equal(want[i].Bias, got[i].Bias) {
+			t.Errorf("bias %v is not restored", i)
+		}
+	}
+
+	if resumedOptimizer.Iter != optimizer.Iter {
+		t.Errorf("iter: want = %v, got = %v", optimizer.Iter, resumedOptimizer.Iter)
+	}
+	for i := range optimizer.M {
+		if !mat.Equal(optimizer.M[i].Weight, resumedOptimizer.M[i].Weight) {
+			t.Errorf("m %v is not restored", i)
+		}
+		if !mat.Equal(optimizer.V[i].Weight, resumedOptimizer.V[i].Weight) {
+			t.Errorf("v %v is not restored", i)
+		}
+	}
+
+	// resumed training continues without panic.
+	resumed.FitSequence(corpus, 1, 2, 3)
+	if resumed.CurrentEpoch != 3 {
+		t.Errorf("epoch: want = 3, got = %v", resumed.CurrentEpoch)
+	}
+}
+
+func TestCheckpointError(t *testing.T) {
+	text := "You say goodbye and I say hello."
+	_, w2id, _ := word.PreProcess(text)
+
+	tests := []struct {
+		name      string
+		optimizer trainer.Optimizer
+		fileName  string
+	}{
+		{
+			name:      "optimizer without state",
+			optimizer: nopOptimizer{},
+			fileName:  "checkpoint.gob",
+		},
+		{
+			name:      "file not found",
+			optimizer: optimizers.InitSDG(0.1),
+			fileName:  "notfound.gob",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+			tr := trainer.InitTrainer(model, tt.optimizer)
+			if err := tr.LoadCheckpoint(tt.fileName); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestCheckpointScheduler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	tests := []struct {
+		name      string
+		scheduler func() schedule.Scheduler
+	}{
+		{
+			name:      "step decay",
+			scheduler: func() schedule.Scheduler { return schedule.InitStepDecay(1, 0.5) },
+		},
+		{
+			name:      "reduce on plateau",
+			scheduler: func() schedule.Scheduler { return schedule.InitReduceOnPlateau(0.5, 0, 0) },
+		},
+	}
+
+	// constant validation result makes plateau.
+	plateau := trainer.Validation(func(m trainer.Model) float64 { return 1 })
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(dir, "checkpoint.gob")
+
+			// full keeps training without interruption.
+			model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+			full := trainer.InitTrainer(
+				model, optimizers.InitSDG(0.1),
+				trainer.Logger(nil),
+				trainer.EvalInterval(1),
+				trainer.Scheduler(tt.scheduler()),
+				trainer.CheckpointFile(fileName),
+				plateau,
+			)
+			full.FitSequence(corpus, 2, 2, 3)
+
+			resumed := trainer.InitTrainer(
+				models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1)), optimizers.InitSDG(0.1),
+				trainer.Logger(nil),
+				trainer.EvalInterval(1),
+				trainer.Scheduler(tt.scheduler()),
+				plateau,
+			)
+			if err := resumed.LoadCheckpoint(fileName); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			full.FitSequence(corpus, 1, 2, 3)
+			resumed.FitSequence(corpus, 1, 2, 3)
+
+			if len(resumed.LRList) != len(full.LRList) {
+				t.Fatalf("want = %v, got = %v", full.LRList, resumed.LRList)
+			}
+			for i := range full.LRList {
+				if math.Abs(full.LRList[i]-resumed.LRList[i]) > 1e-12 {
+					t.Errorf("want = %v, got = %v", full.LRList, resumed.LRList)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestLoadCheckpointShapeMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "checkpoint.gob")
+
+	text := "You say goodbye and I say hello."
+	_, w2id, _ := word.PreProcess(text)
+
+	tr := trainer.InitTrainer(models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1)), optimizers.InitSDG(0.1))
+	if err := tr.SaveCheckpoint(fileName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	model := models.InitRNNLM(len(w2id), 5, 6, matutil.NewRand(1))
+	before := mat.DenseCopyOf(model.RNN.Param.Weight)
+	loaded := trainer.InitTrainer(model, optimizers.InitSDG(0.1))
+	if err := loaded.LoadCheckpoint(fileName); err == nil {
+		t.Fatal("expected error")
+	}
+	if !mat.Equal(before, model.RNN.Param.Weight) {
+		t.Error("params are changed by failed load")
+	}
+}
+
+// errRecorder records errors reported during training.
+type errRecorder struct {
+	recorder
+	errs []error
+}
+
+func (r *errRecorder) OnError(e trainer.Event, err error) {
+	r.errs = append(r.errs, err)
+}
+
+func TestSaveCheckpointErrorReported(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+	fileName := filepath.Join("notfound", "checkpoint.gob")
+
+	var text1, json1 bytes.Buffer
+	r := &errRecorder{}
+	tr := trainer.InitTrainer(
+		models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1)), optimizers.InitSDG(0.1),
+		trainer.Logger(trainer.NewTextLogger(&text1)),
+		trainer.Callbacks(r, trainer.NewJSONLogger(&json1)),
+		trainer.EvalInterval(100),
+		trainer.CheckpointFile(fileName),
+	)
+	tr.FitSequence(corpus, 2, 2, 3)
+
+	if len(r.errs) != 2 {
+		t.Fatalf("want = 2, got = %v", r.errs)
+	}
+	if !strings.Contains(text1.String(), "failed to create checkpoint file") {
+		t.Errorf("text logger does not log error: %q", text1.String())
+	}
+	if !strings.Contains(json1.String(), `"event":"error"`) {
+		t.Errorf("json logger does not log error: %q", json1.String())
+	}
+}
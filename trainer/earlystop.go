@@ -0,0 +1,116 @@
+package trainer
+
+import (
+	"github.com/po3rin/gonnp/params"
+)
+
+// monitors are names of metrics which early stopping can monitor.
+const (
+	// MonitorLoss is average training loss of epoch.
+	MonitorLoss = "loss"
+	// MonitorValid is result of Validator. lower is better like perplexity.
+	MonitorValid = "valid"
+)
+
+// EarlyStop keeps state of early stopping.
+type EarlyStop struct {
+	Monitor  string
+	Patience int
+	// Best is best value of monitored metric & BestEpoch is the epoch (0-origin).
+	Best      float64
+	BestEpoch int
+	// Stopped reports whether training has been stopped.
+	Stopped   bool
+	params    []params.Param
+	wait      int
+	direction Direction
+}
+
+// EarlyStopping stops training when monitored metric has not improved for patience epochs.
+// params of the best epoch are restored when training finishes.
+// monitor is MonitorLoss, MonitorValid which needs Validation or ValidationData option,
+// or name of Metric set by ValidationData like "accuracy" whose Direction decides improvement.
+// InitTrainer panics if monitor is unknown.
+func EarlyStopping(monitor string, patience int) func(*Train) {
+	return func(t *Train) {
+		t.EarlyStop = &EarlyStop{
+			Monitor:  monitor,
+			Patience: patience,
+		}
+	}
+}
+
+// monitorDirection checks that monitor of early stopping can be observed & returns its direction.
+func (t *Train) monitorDirection(monitor string) Direction {
+	switch monitor {
+	case MonitorLoss:
+		return Minimize
+	case MonitorValid:
+		if t.Validator == nil {
+			panic("gonnp: early stopping monitors valid, but Validator is not set")
+		}
+		return Minimize
+	}
+	m, ok := t.metric(monitor)
+	if !ok {
+		panic("gonnp: unknown monitor for early stopping: " + monitor)
+	}
+	return m.Direction
+}
+
+// observe updates best params with metric of the epoch & decides whether to stop.
+func (s *EarlyStop) observe(e Event, model Model) {
+	var v float64
+	switch s.Monitor {
+	case MonitorLoss:
+		v = e.Loss
+	case MonitorValid:
+		v = e.Valid
+	default:
+		v = e.Metrics[s.Monitor]
+	}
+
+	higher := s.direction == Maximize
+	if s.params == nil || (higher && v > s.Best) || (!higher && v < s.Best) {
+		s.Best = v
+		s.BestEpoch = e.Epoch
+		s.params = uniqueParams(model.GetParams())
+		s.wait = 0
+		return
+	}
+
+	s.wait++
+	if s.wait >= s.Patience {
+		s.Stopped = true
+	}
+}
+
+// stopped reports whether training should be stopped by early stopping.
+func (t *Train) stopped() bool {
+	return t.EarlyStop != nil && t.EarlyStop.Stopped
+}
+
+// reset clears state of previous training.
+func (s *EarlyStop) reset() {
+	s.Best, s.BestEpoch = 0, 0
+	s.Stopped = false
+	s.params = nil
+	s.wait = 0
+}
+
+// beginFit resets state of early stopping so that each Fit is stopped by its own epochs.
+func (t *Train) beginFit() {
+	if t.EarlyStop != nil {
+		t.EarlyStop.reset()
+	}
+}
+
+// endFit restores params of the best epoch if early stopping is set
+// & saves checkpoint again so that it has the restored params.
+func (t *Train) endFit(maxIters int) {
+	if t.EarlyStop == nil || t.EarlyStop.params == nil {
+		return
+	}
+	t.Model.UpdateParams(uniqueParams(t.EarlyStop.params))
+	t.saveCheckpoint(t.event(0, maxIters))
+}
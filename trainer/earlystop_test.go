@@ -0,0 +1,260 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"github.com/po3rin/gonnp/word"
+	"gonum.org/v1/gonum/mat"
+)
+
+// snapshot keeps copy of first weight at the end of each epoch.
+type snapshot struct {
+	trainer.SilentLogger
+	weights []mat.Matrix
+}
+
+func (s *snapshot) OnEpochEnd(e trainer.Event) {
+	s.weights = append(s.weights, mat.DenseCopyOf(e.Params()[0].Weight))
+}
+
+func TestEarlyStopping(t *testing.T) {
+	tests := []struct {
+		name          string
+		valid         []float64
+		patience      int
+		wantEpoch     float64
+		wantBestEpoch int
+	}{
+		{
+			name:          "stop after patience",
+			valid:         []float64{3, 1, 2, 2, 0},
+			patience:      2,
+			wantEpoch:     4,
+			wantBestEpoch: 1,
+		},
+		{
+			name:          "improvement resets patience",
+			valid:         []float64{3, 2, 4, 1, 5},
+			patience:      2,
+			wantEpoch:     5,
+			wantBestEpoch: 3,
+		},
+	}
+
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var i int
+			validator := func(m trainer.Model) float64 {
+				v := tt.valid[i]
+				i++
+				return v
+			}
+
+			model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+			s := &snapshot{}
+			tr := trainer.InitTrainer(
+				model, optimizers.InitSDG(0.1),
+				trainer.Logger(trainer.SilentLogger{}),
+				trainer.Callbacks(s),
+				trainer.Validation(validator),
+				trainer.EarlyStopping(trainer.MonitorValid, tt.patience),
+			)
+			tr.FitSequence(corpus, len(tt.valid), 2, 3)
+
+			if tr.CurrentEpoch != tt.wantEpoch {
+				t.Errorf("want = %v, got = %v", tt.wantEpoch, tr.CurrentEpoch)
+			}
+			if tr.EarlyStop.BestEpoch != tt.wantBestEpoch {
+				t.Errorf("want = %v, got = %v", tt.wantBestEpoch, tr.EarlyStop.BestEpoch)
+			}
+			if want := tt.valid[tt.wantBestEpoch]; tr.EarlyStop.Best != want {
+				t.Errorf("want = %v, got = %v", want, tr.EarlyStop.Best)
+			}
+			if got := model.GetParams()[0].Weight; !mat.Equal(got, s.weights[tt.wantBestEpoch]) {
+				t.Error("params of the best epoch are not restored")
+			}
+		})
+	}
+}
+
+func TestEarlyStoppingMonitorPanic(t *testing.T) {
+	x := mat.NewDense(2, 2, []float64{1, 0, 0, 1})
+
+	tests := []struct {
+		name    string
+		options []trainer.OptionFunc
+	}{
+		{
+			name:    "unknown monitor",
+			options: []trainer.OptionFunc{trainer.EarlyStopping("unknown", 1)},
+		},
+		{
+			name:    "valid without Validator",
+			options: []trainer.OptionFunc{trainer.EarlyStopping(trainer.MonitorValid, 1)},
+		},
+		{
+			name: "metric which is not set",
+			options: []trainer.OptionFunc{
+				trainer.ValidationData(x, x, trainer.Accuracy()),
+				trainer.EarlyStopping("top2_accuracy", 1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			trainer.InitTrainer(models.NewTwoLayerNet(2, 4, 2, matutil.NewRand(1)), optimizers.InitSDG(0.1), tt.options...)
+		})
+	}
+}
+
+func TestEarlyStoppingMetricDirection(t *testing.T) {
+	x := mat.NewDense(4, 2, []float64{1, 0, 0, 1, 1, 0, 0, 1})
+
+	tests := []struct {
+		name          string
+		direction     trainer.Direction
+		wantEpoch     float64
+		wantBestEpoch int
+	}{
+		{
+			name:          "minimize",
+			direction:     trainer.Minimize,
+			wantEpoch:     4,
+			wantBestEpoch: 1,
+		},
+		{
+			name:          "maximize",
+			direction:     trainer.Maximize,
+			wantEpoch:     3,
+			wantBestEpoch: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			values := []float64{3, 1, 2, 2, 0}
+			var i int
+			metric := trainer.Metric{
+				Name: "custom",
+				Eval: func(score, teacher mat.Matrix) float64 {
+					v := values[i]
+					i++
+					return v
+				},
+				Direction: tt.direction,
+			}
+
+			tr := trainer.InitTrainer(
+				models.NewTwoLayerNet(2, 4, 2, matutil.NewRand(1)), optimizers.InitSDG(0.1),
+				trainer.Logger(trainer.SilentLogger{}),
+				trainer.ValidationData(x, x, metric),
+				trainer.EarlyStopping("custom", 2),
+			)
+			tr.Fit(x, x, len(values), 2)
+
+			if tr.CurrentEpoch != tt.wantEpoch {
+				t.Errorf("want = %v, got = %v", tt.wantEpoch, tr.CurrentEpoch)
+			}
+			if tr.EarlyStop.BestEpoch != tt.wantBestEpoch {
+				t.Errorf("want = %v, got = %v", tt.wantBestEpoch, tr.EarlyStop.BestEpoch)
+			}
+		})
+	}
+}
+
+func TestEarlyStoppingRefit(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	valid := []float64{3, 1, 2, 2, 5, 4, 3}
+	var i int
+	validator := func(m trainer.Model) float64 {
+		v := valid[i]
+		i++
+		return v
+	}
+
+	tr := trainer.InitTrainer(
+		models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1)), optimizers.InitSDG(0.1),
+		trainer.Logger(trainer.SilentLogger{}),
+		trainer.Validation(validator),
+		trainer.EarlyStopping(trainer.MonitorValid, 2),
+	)
+	tr.FitSequence(corpus, 5, 2, 3)
+	if tr.CurrentEpoch != 4 {
+		t.Fatalf("want = 4, got = %v", tr.CurrentEpoch)
+	}
+
+	// state of early stopping in first Fit does not stop second Fit.
+	tr.FitSequence(corpus, 3, 2, 3)
+	if tr.CurrentEpoch != 7 {
+		t.Errorf("want = 7, got = %v", tr.CurrentEpoch)
+	}
+	if tr.EarlyStop.Best != 3 || tr.EarlyStop.BestEpoch != 6 {
+		t.Errorf("want = (3, 6), got = (%v, %v)", tr.EarlyStop.Best, tr.EarlyStop.BestEpoch)
+	}
+}
+
+func TestEarlyStoppingCheckpoint(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	dir, err := ioutil.TempDir("", "gonnp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "checkpoint.gob")
+
+	valid := []float64{3, 1, 2, 2}
+	var i int
+	validator := func(m trainer.Model) float64 {
+		v := valid[i]
+		i++
+		return v
+	}
+
+	s := &snapshot{}
+	tr := trainer.InitTrainer(
+		models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1)), optimizers.InitSDG(0.1),
+		trainer.Logger(trainer.SilentLogger{}),
+		trainer.Callbacks(s),
+		trainer.Validation(validator),
+		trainer.EarlyStopping(trainer.MonitorValid, 2),
+		trainer.CheckpointFile(fileName),
+	)
+	tr.FitSequence(corpus, len(valid), 2, 3)
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	resumed := trainer.InitTrainer(model, optimizers.InitSDG(0.1))
+	if err := resumed.LoadCheckpoint(fileName); err != nil {
+		t.Fatal(err)
+	}
+	if got := model.GetParams()[0].Weight; !mat.Equal(got, s.weights[1]) {
+		t.Error("checkpoint does not have params of the best epoch")
+	}
+	if resumed.CurrentEpoch != 4 {
+		t.Errorf("want = 4, got = %v", resumed.CurrentEpoch)
+	}
+}
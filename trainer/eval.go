@@ -0,0 +1,74 @@
+package trainer
+
+import (
+	"math"
+
+	"github.com/po3rin/gonnp/word"
+)
+
+// Validator evaluates model at the end of each epoch. ex. perplexity of validation data.
+type Validator func(m Model) float64
+
+// Validation sets validator called at the end of each epoch.
+// results are recorded in ValidList.
+func Validation(v Validator) func(*Train) {
+	return func(t *Train) {
+		t.Validator = v
+	}
+}
+
+// PerplexityValidator returns validator which evaluates perplexity of sequence model on corpus.
+// hidden state of model is restored after each validation, so training is not affected.
+func PerplexityValidator(corpus word.Corpus, batchSize, timeSize int) (Validator, error) {
+	if err := checkSequence(corpus, batchSize, timeSize); err != nil {
+		return nil, err
+	}
+
+	return func(m Model) float64 {
+		sm, ok := m.(SequenceModel)
+		if !ok {
+			panic("gonnp: model does not support hidden state for evaluating perplexity")
+		}
+		ppl, err := EvalPerplexity(sm, corpus, batchSize, timeSize)
+		if err != nil {
+			// corpus is checked above.
+			panic(err)
+		}
+		return ppl
+	}, nil
+}
+
+// EvalPerplexity evaluates perplexity of sequence model on corpus without updating params.
+// hidden state is reset before evaluation and restored after evaluation.
+func EvalPerplexity(model SequenceModel, corpus word.Corpus, batchSize, timeSize int) (float64, error) {
+	if err := checkSequence(corpus, batchSize, timeSize); err != nil {
+		return 0, err
+	}
+
+	xs := corpus[:len(corpus)-1]
+	ts := corpus[1:]
+	maxIters := len(xs) / (batchSize * timeSize)
+
+	state := model.State()
+	defer model.SetState(state)
+	model.ResetState()
+
+	var totalLoss float64
+	for i := 0; i < maxIters; i++ {
+		bx := sequenceBatch(xs, batchSize, timeSize, i*timeSize)
+		bt := sequenceBatch(ts, batchSize, timeSize, i*timeSize)
+		totalLoss += model.Forward(bt, bx)
+	}
+
+	return math.Exp(totalLoss / float64(maxIters)), nil
+}
+
+// validate runs validator if it is set. it reports whether validator is run.
+func (t *Train) validate() (float64, bool) {
+	if t.Validator == nil {
+		return 0, false
+	}
+	v := t.Validator(t.Model)
+	t.ValidList = append(t.ValidList, v)
+	return v, true
+}
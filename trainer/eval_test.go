@@ -0,0 +1,210 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/trainer"
+	"github.com/po3rin/gonnp/word"
+	"gonum.org/v1/gonum/mat"
+)
+
+// constModel returns constant loss and counts calls.
+type constModel struct {
+	loss     float64
+	forward  int
+	backward int
+	reset    int
+	state    mat.Matrix
+}
+
+func (c *constModel) Forward(teacher mat.Matrix, x ...mat.Matrix) float64 {
+	c.forward++
+	return c.loss
+}
+
+func (c *constModel) Backward() mat.Matrix {
+	c.backward++
+	return nil
+}
+
+func (c *constModel) State() mat.Matrix              { return c.state }
+func (c *constModel) SetState(h mat.Matrix)          { c.state = h }
+func (c *constModel) ResetState()                    { c.reset++; c.state = nil }
+func (c *constModel) GetParams() []params.Param      { return nil }
+func (c *constModel) GetGrads() []params.Grad        { return nil }
+func (c *constModel) UpdateParams(ps []params.Param) {}
+
+func TestEvalPerplexity(t *testing.T) {
+	tests := []struct {
+		name        string
+		loss        float64
+		corpus      word.Corpus
+		batchSize   int
+		timeSize    int
+		want        float64
+		wantForward int
+	}{
+		{
+			name:        "zero loss",
+			loss:        0,
+			corpus:      word.Corpus{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			batchSize:   1,
+			timeSize:    3,
+			want:        1,
+			wantForward: 3,
+		},
+		{
+			name:        "log 5",
+			loss:        math.Log(5),
+			corpus:      word.Corpus{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			batchSize:   2,
+			timeSize:    2,
+			want:        5,
+			wantForward: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			state := mat.NewDense(1, 1, []float64{1})
+			m := &constModel{loss: tt.loss, state: state}
+			got, err := trainer.EvalPerplexity(m, tt.corpus, tt.batchSize, tt.timeSize)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-7 {
+				t.Errorf("want = %v, got = %v", tt.want, got)
+			}
+			if m.forward != tt.wantForward {
+				t.Errorf("forward: want = %v, got = %v", tt.wantForward, m.forward)
+			}
+			if m.backward != 0 {
+				t.Errorf("backward is called %v times", m.backward)
+			}
+			if m.reset != 1 {
+				t.Errorf("reset: want = 1, got = %v", m.reset)
+			}
+			if m.state != state {
+				t.Errorf("state is not restored: want = %v, got = %v", state, m.state)
+			}
+		})
+	}
+}
+
+func TestEvalPerplexityError(t *testing.T) {
+	tests := []struct {
+		name      string
+		corpus    word.Corpus
+		batchSize int
+		timeSize  int
+	}{
+		{
+			name:      "empty corpus",
+			corpus:    word.Corpus{},
+			batchSize: 1,
+			timeSize:  3,
+		},
+		{
+			name:      "corpus shorter than batchSize * timeSize + 1",
+			corpus:    word.Corpus{0, 1, 2, 3, 4, 5},
+			batchSize: 2,
+			timeSize:  3,
+		},
+		{
+			name:      "zero batch size",
+			corpus:    word.Corpus{0, 1, 2, 3, 4, 5},
+			batchSize: 0,
+			timeSize:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := &constModel{}
+			if _, err := trainer.EvalPerplexity(m, tt.corpus, tt.batchSize, tt.timeSize); err == nil {
+				t.Error("expected error")
+			}
+			if m.forward != 0 {
+				t.Errorf("forward: want = 0, got = %v", m.forward)
+			}
+			if _, err := trainer.PerplexityValidator(tt.corpus, tt.batchSize, tt.timeSize); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestEvalPerplexityKeepsParams(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	want := mat.DenseCopyOf(model.Affine.Param.Weight)
+
+	ppl, err := trainer.EvalPerplexity(model, corpus, 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.IsNaN(ppl) || math.IsInf(ppl, 0) || ppl < 1 {
+		t.Errorf("invalid perplexity %v", ppl)
+	}
+	if !mat.Equal(model.Affine.Param.Weight, want) {
+		t.Error("params are updated by evaluation")
+	}
+}
+
+func TestValidation(t *testing.T) {
+	batchSize := 2
+	timeSize := 3
+	maxEpoch := 3
+
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	validator, err := trainer.PerplexityValidator(corpus, 1, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	optimizer := optimizers.InitSDG(0.1)
+	tr := trainer.InitTrainer(
+		model, optimizer,
+		trainer.EvalInterval(1),
+		trainer.Validation(validator),
+	)
+
+	tr.FitSequence(corpus, maxEpoch, batchSize, timeSize)
+	if len(tr.ValidList) != maxEpoch {
+		t.Fatalf("want = %v, got = %v", maxEpoch, len(tr.ValidList))
+	}
+}
+
+func TestPerplexityValidatorKeepsState(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	validator, err := trainer.PerplexityValidator(corpus, 1, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	x := mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5})
+	model.Forward(x, x)
+	want := mat.DenseCopyOf(model.State())
+
+	validator(model)
+	if !mat.Equal(model.State(), want) {
+		t.Errorf("hidden state is changed by validation: want = %v, got = %v", want, model.State())
+	}
+}
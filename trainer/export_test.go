@@ -3,3 +3,4 @@
 package trainer
 
 var RmDuplicate = rmDuplicate
+var SequenceBatch = sequenceBatch
@@ -1,6 +1,7 @@
 package trainer
 
 import (
+	"math"
 	"reflect"
 
 	"github.com/po3rin/gonnp/params"
@@ -36,3 +37,50 @@ func rmDuplicate(params []params.Param, grads []params.Grad) ([]params.Param, []
 	}
 	return params, grads
 }
+
+// ClipGrads rescales grads so that their global L2 norm is at most maxNorm.
+// Weight, WeightH & Bias are all counted. it returns the norm before clipping.
+func ClipGrads(grads []params.Grad, maxNorm float64) float64 {
+	var sum float64
+	for _, g := range grads {
+		if g.Weight != nil {
+			sum += sqSum(g.Weight)
+		}
+		if g.WeightH != nil {
+			sum += sqSum(g.WeightH)
+		}
+		if g.Bias != nil {
+			sum += sqSum(g.Bias)
+		}
+	}
+	norm := math.Sqrt(sum)
+
+	rate := maxNorm / (norm + 1e-6)
+	if rate >= 1 {
+		return norm
+	}
+
+	for i, g := range grads {
+		if g.Weight != nil {
+			d := mat.DenseCopyOf(g.Weight)
+			d.Scale(rate, d)
+			grads[i].Weight = d
+		}
+		if g.WeightH != nil {
+			d := mat.DenseCopyOf(g.WeightH)
+			d.Scale(rate, d)
+			grads[i].WeightH = d
+		}
+		if g.Bias != nil {
+			v := mat.VecDenseCopyOf(g.Bias)
+			v.ScaleVec(rate, v)
+			grads[i].Bias = v
+		}
+	}
+	return norm
+}
+
+func sqSum(x mat.Matrix) float64 {
+	n := mat.Norm(x, 2)
+	return n * n
+}
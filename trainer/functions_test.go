@@ -0,0 +1,88 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/trainer"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestClipGrads(t *testing.T) {
+	rate := 1 / (5 + 1e-6)
+	tests := []struct {
+		name     string
+		grads    []params.Grad
+		maxNorm  float64
+		wantNorm float64
+		want     []params.Grad
+	}{
+		{
+			name: "clipped",
+			grads: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{2, 0, 0, 0}),
+					WeightH: mat.NewDense(1, 2, []float64{0, -2}),
+					Bias:    mat.NewVecDense(2, []float64{1, 0}),
+				},
+				params.Grad{
+					Weight: mat.NewDense(1, 2, []float64{0, 4}),
+				},
+			},
+			maxNorm:  1,
+			wantNorm: 5,
+			want: []params.Grad{
+				params.Grad{
+					Weight:  mat.NewDense(2, 2, []float64{2 * rate, 0, 0, 0}),
+					WeightH: mat.NewDense(1, 2, []float64{0, -2 * rate}),
+					Bias:    mat.NewVecDense(2, []float64{rate, 0}),
+				},
+				params.Grad{
+					Weight: mat.NewDense(1, 2, []float64{0, 4 * rate}),
+				},
+			},
+		},
+		{
+			name: "not clipped",
+			grads: []params.Grad{
+				params.Grad{
+					Weight: mat.NewDense(1, 2, []float64{3, 4}),
+					Bias:   mat.NewVecDense(1, []float64{0}),
+				},
+			},
+			maxNorm:  10,
+			wantNorm: 5,
+			want: []params.Grad{
+				params.Grad{
+					Weight: mat.NewDense(1, 2, []float64{3, 4}),
+					Bias:   mat.NewVecDense(1, []float64{0}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			norm := trainer.ClipGrads(tt.grads, tt.maxNorm)
+			if math.Abs(norm-tt.wantNorm) > 1e-7 {
+				t.Errorf("norm: want = %v, got = %v", tt.wantNorm, norm)
+			}
+			for i, g := range tt.grads {
+				w := tt.want[i]
+				if !mat.EqualApprox(g.Weight, w.Weight, 1e-7) {
+					t.Errorf("weight: want = %v, got = %v", w.Weight, g.Weight)
+				}
+				if w.WeightH != nil && !mat.EqualApprox(g.WeightH, w.WeightH, 1e-7) {
+					t.Errorf("weightH: want = %v, got = %v", w.WeightH, g.WeightH)
+				}
+				if w.Bias != nil && !mat.EqualApprox(g.Bias, w.Bias, 1e-7) {
+					t.Errorf("bias: want = %v, got = %v", w.Bias, g.Bias)
+				}
+			}
+		})
+	}
+}
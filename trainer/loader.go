@@ -0,0 +1,66 @@
+package trainer
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// DataLoader iterates mini-batches of each epoch. ex. dataset.Loader.
+type DataLoader interface {
+	// Len returns number of mini-batches in an epoch.
+	Len() int
+	// Reset starts new epoch. ex. shuffles data.
+	Reset()
+	// Next returns next mini-batch. ok is false at the end of epoch.
+	// x is passed to Model.Forward as variadic inputs.
+	Next() (x []mat.Matrix, teacher mat.Matrix, ok bool)
+}
+
+// FitLoader trains from mini-batches which loader returns.
+// epoch without mini-batch is skipped without passing loss to scheduler & early stopping.
+func (t *Train) FitLoader(loader DataLoader, maxEpoch int) {
+	maxIters := loader.Len()
+	var totalLoss, epochLoss float64
+	var lossCount int
+
+	t.beginFit()
+	for i := 0; i < maxEpoch && !t.stopped(); i++ {
+		loader.Reset()
+
+		t.beginEpoch(maxIters)
+		var rows int
+		for j := 0; ; j++ {
+			bx, bt, ok := loader.Next()
+			if !ok {
+				break
+			}
+
+			t.scheduleLR()
+			loss := t.Model.Forward(bt, bx...)
+			t.Model.Backward()
+			t.update()
+
+			// loss of the epoch is weighted by rows as the last partial batch may be smaller.
+			n, _ := bt.Dims()
+			totalLoss += loss
+			epochLoss += loss * float64(n)
+			lossCount++
+			rows += n
+
+			e := t.event(j, maxIters)
+			e.Loss = loss
+			if j%t.EvalInterval == 0 {
+				e.Eval = true
+				e.AvgLoss = totalLoss / float64(lossCount)
+				totalLoss, lossCount = 0, 0
+			}
+			t.endBatch(e)
+		}
+		if rows == 0 {
+			// no loss of the epoch is passed to scheduler & early stopping.
+			continue
+		}
+		t.endEpoch(epochLoss/float64(rows), maxIters)
+		epochLoss = 0
+	}
+	t.endFit(maxIters)
+}
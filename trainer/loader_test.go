@@ -0,0 +1,169 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/po3rin/gonnp/dataset"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"gonum.org/v1/gonum/mat"
+)
+
+// sliceLoader returns fixed mini-batches.
+type sliceLoader struct {
+	batches []mat.Matrix
+	i       int
+	reset   int
+}
+
+func (s *sliceLoader) Len() int { return len(s.batches) }
+
+func (s *sliceLoader) Reset() {
+	s.i = 0
+	s.reset++
+}
+
+func (s *sliceLoader) Next() (x []mat.Matrix, teacher mat.Matrix, ok bool) {
+	if s.i >= len(s.batches) {
+		return nil, nil, false
+	}
+	b := s.batches[s.i]
+	s.i++
+	return []mat.Matrix{b}, b, true
+}
+
+func TestFitLoader(t *testing.T) {
+	loader := &sliceLoader{
+		batches: []mat.Matrix{
+			mat.NewDense(3, 1, []float64{0, 1, 2}),
+			mat.NewDense(1, 1, []float64{3}),
+		},
+	}
+
+	m := &batchModel{}
+	r := &recorder{}
+	tr := trainer.InitTrainer(m, optimizers.InitSDG(0.1), trainer.Logger(nil), trainer.Callbacks(r))
+	tr.FitLoader(loader, 2)
+
+	want := [][]float64{{0, 1, 2}, {3}, {0, 1, 2}, {3}}
+	if !reflect.DeepEqual(m.batches, want) {
+		t.Errorf("want = %v, got = %v", want, m.batches)
+	}
+	if loader.reset != 2 {
+		t.Errorf("want = 2, got = %v", loader.reset)
+	}
+	if len(r.events) != 8 {
+		t.Errorf("want = 8, got = %v", r.events)
+	}
+}
+
+func TestFitDatasetLoader(t *testing.T) {
+	x := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+	loader := dataset.NewLoader(
+		dataset.NewMatrix(x, x), 2,
+		dataset.Shuffle(false),
+		dataset.KeepPartialBatch(true),
+		dataset.Prefetch(2),
+	)
+	defer loader.Close()
+
+	m := &batchModel{}
+	tr := trainer.InitTrainer(m, optimizers.InitSDG(0.1), trainer.Logger(nil))
+	tr.FitLoader(loader, 2)
+
+	want := [][]float64{{0, 1}, {2, 3}, {4}, {0, 1}, {2, 3}, {4}}
+	if !reflect.DeepEqual(m.batches, want) {
+		t.Errorf("want = %v, got = %v", want, m.batches)
+	}
+}
+
+func TestFitLoaderNoBatch(t *testing.T) {
+	x := mat.NewDense(3, 1, []float64{0, 1, 2})
+	x3D := []mat.Matrix{
+		mat.NewDense(1, 1, []float64{0}),
+		mat.NewDense(1, 1, []float64{1}),
+		mat.NewDense(1, 1, []float64{2}),
+	}
+	tests := []struct {
+		name string
+		fit  func(tr *trainer.Train)
+	}{
+		{
+			name: "empty loader",
+			fit:  func(tr *trainer.Train) { tr.FitLoader(&sliceLoader{}, 2) },
+		},
+		{
+			name: "batch size is larger than data",
+			fit:  func(tr *trainer.Train) { tr.Fit(x, x, 2, 4) },
+		},
+		{
+			name: "batch size is larger than 3D data",
+			fit:  func(tr *trainer.Train) { tr.Fit3D(x3D, x, 2, 4) },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := &batchModel{}
+			s := &observer{}
+			tr := trainer.InitTrainer(
+				m, optimizers.InitSDG(0.1),
+				trainer.Logger(nil),
+				trainer.Scheduler(s),
+				trainer.EarlyStopping(trainer.MonitorLoss, 1),
+			)
+			tt.fit(tr)
+
+			if len(m.batches) != 0 {
+				t.Errorf("want no mini-batch, got = %v", m.batches)
+			}
+			if len(s.metrics) != 0 {
+				t.Errorf("scheduler observes %v", s.metrics)
+			}
+		})
+	}
+
+	t.Run("loader returns no mini-batch", func(t *testing.T) {
+		loader := &emptyLoader{sliceLoader{batches: []mat.Matrix{mat.NewDense(1, 1, nil)}}}
+		r := &recorder{}
+		s := &observer{}
+		tr := trainer.InitTrainer(
+			&batchModel{}, optimizers.InitSDG(0.1),
+			trainer.Logger(nil),
+			trainer.Callbacks(r),
+			trainer.Scheduler(s),
+		)
+		tr.FitLoader(loader, 2)
+
+		for _, e := range r.events {
+			if strings.HasPrefix(e, "end") {
+				t.Errorf("OnEpochEnd is called without mini-batch: %v", r.events)
+			}
+		}
+		if len(s.metrics) != 0 {
+			t.Errorf("scheduler observes %v", s.metrics)
+		}
+	})
+}
+
+// emptyLoader has mini-batches in Len, but returns no mini-batch.
+type emptyLoader struct {
+	sliceLoader
+}
+
+func (e *emptyLoader) Next() (x []mat.Matrix, teacher mat.Matrix, ok bool) {
+	return nil, nil, false
+}
+
+// observer records metrics passed to scheduler.
+type observer struct {
+	metrics []float64
+}
+
+func (o *observer) LR(initLR float64, epoch, iter int) float64 { return initLR }
+func (o *observer) Observe(metric float64)                     { o.metrics = append(o.metrics, metric) }
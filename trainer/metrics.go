@@ -0,0 +1,190 @@
+package trainer
+
+import (
+	"fmt"
+
+	"github.com/po3rin/gonnp/dataset"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Predictor is model which predicts scores (N, C) from x without loss like TwoLayerNet.
+type Predictor interface {
+	Predict(x mat.Matrix) mat.Matrix
+}
+
+// Direction is which direction of metric is improvement.
+type Direction int
+
+const (
+	// Minimize means lower is better like loss.
+	Minimize Direction = iota
+	// Maximize means higher is better like accuracy.
+	Maximize
+)
+
+// Metric evaluates scores predicted by model with teacher.
+// teacher is one-hot (N, C) or labels (N, 1).
+type Metric struct {
+	Name      string
+	Eval      func(score, teacher mat.Matrix) float64
+	Direction Direction
+}
+
+// History is record of each epoch.
+type History struct {
+	// Loss is average training loss.
+	Loss []float64
+	// Valid is result of Validator like validation loss set by ValidationData.
+	Valid []float64
+	// Metrics is results of metrics on validation data by name.
+	Metrics map[string][]float64
+}
+
+// ValidationData sets validation data evaluated at the end of each epoch.
+// validation loss is recorded as result of Validator & metrics need model which implements Predictor.
+// it can not be used with Validation option. data is evaluated by ValidBatchSize rows.
+func ValidationData(x, teacher mat.Matrix, metrics ...Metric) func(*Train) {
+	return func(t *Train) {
+		t.validData = dataset.NewMatrix(x, teacher)
+		t.Metrics = metrics
+	}
+}
+
+// ValidationBatchSize sets number of rows of validation data passed to model at once.
+func ValidationBatchSize(size int) func(*Train) {
+	return func(t *Train) {
+		t.ValidBatchSize = size
+	}
+}
+
+// validationLoss is Validator of ValidationData. it is average loss of mini-batches weighted by their rows.
+func (t *Train) validationLoss(m Model) float64 {
+	n := t.validData.Len()
+	var loss float64
+	for start := 0; start < n; start += t.ValidBatchSize {
+		x, teacher := t.validBatch(start)
+		rows, _ := x.Dims()
+		loss += m.Forward(teacher, x) * float64(rows)
+	}
+	return loss / float64(n)
+}
+
+// validBatch returns rows of validation data from start without copy.
+func (t *Train) validBatch(start int) (x, teacher *mat.Dense) {
+	end := start + t.ValidBatchSize
+	if n := t.validData.Len(); end > n {
+		end = n
+	}
+	_, xc := t.validData.X.Dims()
+	_, tc := t.validData.Teacher.Dims()
+	x = t.validData.X.Slice(start, end, 0, xc).(*mat.Dense)
+	teacher = t.validData.Teacher.Slice(start, end, 0, tc).(*mat.Dense)
+	return x, teacher
+}
+
+// Accuracy is ratio of samples whose highest score is correct label.
+func Accuracy() Metric {
+	return Metric{
+		Name: "accuracy",
+		Eval: func(score, teacher mat.Matrix) float64 {
+			return topKAccuracy(score, teacher, 1)
+		},
+		Direction: Maximize,
+	}
+}
+
+// TopKAccuracy is ratio of samples whose correct label is in top k scores.
+func TopKAccuracy(k int) Metric {
+	return Metric{
+		Name: fmt.Sprintf("top%d_accuracy", k),
+		Eval: func(score, teacher mat.Matrix) float64 {
+			return topKAccuracy(score, teacher, k)
+		},
+		Direction: Maximize,
+	}
+}
+
+func topKAccuracy(score, teacher mat.Matrix, k int) float64 {
+	n, c := score.Dims()
+	_, tc := teacher.Dims()
+
+	var correct int
+	for i := 0; i < n; i++ {
+		label := int(teacher.At(i, 0))
+		if tc == c && c > 1 {
+			label = floats.MaxIdx(mat.Row(nil, i, teacher))
+		}
+
+		// a class tied with label ranks above it if its index is lower like floats.MaxIdx.
+		row := mat.Row(nil, i, score)
+		var rank int
+		for j, v := range row {
+			if v > row[label] || (v == row[label] && j < label) {
+				rank++
+			}
+		}
+		if rank < k {
+			correct++
+		}
+	}
+	return float64(correct) / float64(n)
+}
+
+// evalMetrics evaluates metrics on validation data.
+func (t *Train) evalMetrics() map[string]float64 {
+	if len(t.Metrics) == 0 {
+		return nil
+	}
+	p, ok := t.Model.(Predictor)
+	if !ok {
+		panic("gonnp: model does not support Predict for evaluating metrics")
+	}
+
+	// scores of mini-batches are stacked so that metrics see all of validation data.
+	var score *mat.Dense
+	n := t.validData.Len()
+	for start := 0; start < n; start += t.ValidBatchSize {
+		x, _ := t.validBatch(start)
+		s := p.Predict(x)
+		if score == nil {
+			_, c := s.Dims()
+			score = mat.NewDense(n, c, nil)
+		}
+		rows, c := s.Dims()
+		score.Slice(start, start+rows, 0, c).(*mat.Dense).Copy(s)
+	}
+
+	result := make(map[string]float64, len(t.Metrics))
+	for _, m := range t.Metrics {
+		result[m.Name] = m.Eval(score, t.validData.Teacher)
+	}
+	return result
+}
+
+// metric returns Metric of name set by ValidationData.
+func (t *Train) metric(name string) (Metric, bool) {
+	for _, m := range t.Metrics {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Metric{}, false
+}
+
+// record appends results of the epoch to History.
+func (h *History) record(e Event) {
+	h.Loss = append(h.Loss, e.Loss)
+	if e.HasValid {
+		h.Valid = append(h.Valid, e.Valid)
+	}
+	if len(e.Metrics) == 0 {
+		return
+	}
+	if h.Metrics == nil {
+		h.Metrics = make(map[string][]float64, len(e.Metrics))
+	}
+	for name, v := range e.Metrics {
+		h.Metrics[name] = append(h.Metrics[name], v)
+	}
+}
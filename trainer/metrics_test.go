@@ -0,0 +1,199 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestAccuracy(t *testing.T) {
+	score := mat.NewDense(4, 3, []float64{
+		0.1, 0.7, 0.2,
+		0.5, 0.1, 0.4,
+		0.2, 0.3, 0.5,
+		0.6, 0.3, 0.1,
+	})
+
+	tests := []struct {
+		name    string
+		metric  trainer.Metric
+		score   mat.Matrix
+		teacher mat.Matrix
+		want    float64
+	}{
+		{
+			name:   "accuracy with one-hot",
+			metric: trainer.Accuracy(),
+			teacher: mat.NewDense(4, 3, []float64{
+				0, 1, 0,
+				0, 0, 1,
+				0, 0, 1,
+				0, 0, 1,
+			}),
+			want: 0.5,
+		},
+		{
+			name:    "accuracy with labels",
+			metric:  trainer.Accuracy(),
+			teacher: mat.NewDense(4, 1, []float64{1, 2, 2, 2}),
+			want:    0.5,
+		},
+		{
+			name:    "top2 accuracy",
+			metric:  trainer.TopKAccuracy(2),
+			teacher: mat.NewDense(4, 1, []float64{1, 2, 2, 2}),
+			want:    0.75,
+		},
+		{
+			name:    "accuracy with all-equal scores",
+			metric:  trainer.Accuracy(),
+			score:   mat.NewDense(4, 3, nil),
+			teacher: mat.NewDense(4, 1, []float64{1, 2, 2, 0}),
+			want:    0.25,
+		},
+		{
+			name:    "top2 accuracy with all-equal scores",
+			metric:  trainer.TopKAccuracy(2),
+			score:   mat.NewDense(4, 3, nil),
+			teacher: mat.NewDense(4, 1, []float64{1, 2, 2, 0}),
+			want:    0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.score
+			if s == nil {
+				s = score
+			}
+			got := tt.metric.Eval(s, tt.teacher)
+			if got != tt.want {
+				t.Errorf("want = %v, got = %v", tt.want, got)
+			}
+		})
+	}
+
+	if name := trainer.TopKAccuracy(5).Name; name != "top5_accuracy" {
+		t.Errorf("want = top5_accuracy, got = %v", name)
+	}
+}
+
+func TestValidationData(t *testing.T) {
+	x := mat.NewDense(8, 2, []float64{
+		1, 0, 0, 1, 1, 0, 0, 1,
+		1, 0, 0, 1, 1, 0, 0, 1,
+	})
+	teacher := mat.NewDense(8, 2, []float64{
+		1, 0, 0, 1, 1, 0, 0, 1,
+		1, 0, 0, 1, 1, 0, 0, 1,
+	})
+
+	var buf bytes.Buffer
+	tr := trainer.InitTrainer(
+		models.NewTwoLayerNet(2, 4, 2, matutil.NewRand(1)), optimizers.InitSDG(0.1),
+		trainer.Logger(trainer.NewTextLogger(&buf)),
+		trainer.ValidationData(x, teacher, trainer.Accuracy(), trainer.TopKAccuracy(2)),
+	)
+	tr.Fit(x, teacher, 3, 4)
+
+	h := tr.History
+	if len(h.Loss) != 3 || len(h.Valid) != 3 {
+		t.Fatalf("want 3 epochs, got loss = %v, valid = %v", h.Loss, h.Valid)
+	}
+	if len(h.Metrics["accuracy"]) != 3 {
+		t.Errorf("want 3 epochs, got = %v", h.Metrics["accuracy"])
+	}
+	for _, v := range h.Metrics["top2_accuracy"] {
+		if v != 1 {
+			t.Errorf("want = 1, got = %v", v)
+		}
+	}
+	if len(tr.ValidList) != 3 {
+		t.Errorf("want = 3, got = %v", len(tr.ValidList))
+	}
+	if !strings.Contains(buf.String(), "| epoch 2 | valid ") || !strings.Contains(buf.String(), " | accuracy ") {
+		t.Errorf("validation is not logged:\n%v", buf.String())
+	}
+}
+
+func TestValidationDataBatch(t *testing.T) {
+	x := mat.NewDense(5, 2, []float64{
+		1, 0, 0, 1, 1, 0, 0, 1, 1, 1,
+	})
+	teacher := mat.NewDense(5, 2, []float64{
+		1, 0, 0, 1, 1, 0, 0, 1, 0, 1,
+	})
+
+	tests := []struct {
+		name      string
+		batchSize int
+	}{
+		{name: "batch smaller than data", batchSize: 2},
+		{name: "batch larger than data", batchSize: 10},
+	}
+
+	model := models.NewTwoLayerNet(2, 4, 2, matutil.NewRand(1))
+	want := model.Forward(teacher, x)
+	wantAcc := trainer.Accuracy().Eval(model.Predict(x), teacher)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tr := trainer.InitTrainer(
+				model, optimizers.InitSDG(0),
+				trainer.Logger(trainer.SilentLogger{}),
+				trainer.ValidationData(x, teacher, trainer.Accuracy()),
+				trainer.ValidationBatchSize(tt.batchSize),
+			)
+			tr.Fit(x, teacher, 1, 5)
+
+			if got := tr.History.Valid[0]; math.Abs(got-want) > 1e-9 {
+				t.Errorf("want = %v, got = %v", want, got)
+			}
+			if got := tr.History.Metrics["accuracy"][0]; got != wantAcc {
+				t.Errorf("want = %v, got = %v", wantAcc, got)
+			}
+		})
+	}
+}
+
+func TestValidationConflict(t *testing.T) {
+	x := mat.NewDense(2, 2, []float64{1, 0, 0, 1})
+	validator := trainer.Validation(func(m trainer.Model) float64 { return 0 })
+
+	tests := []struct {
+		name    string
+		options []trainer.OptionFunc
+	}{
+		{
+			name:    "Validation first",
+			options: []trainer.OptionFunc{validator, trainer.ValidationData(x, x)},
+		},
+		{
+			name:    "ValidationData first",
+			options: []trainer.OptionFunc{trainer.ValidationData(x, x), validator},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			trainer.InitTrainer(models.NewTwoLayerNet(2, 4, 2, matutil.NewRand(1)), optimizers.InitSDG(0.1), tt.options...)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/trainer"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSeed(t *testing.T) {
+	x := mat.NewDense(8, 2, []float64{
+		1, 0, 0, 1, 1, 1, 0, 0,
+		1, 0, 0, 1, 1, 1, 0, 0,
+	})
+	teacher := mat.NewDense(8, 2, []float64{
+		1, 0, 0, 1, 0, 1, 1, 0,
+		1, 0, 0, 1, 0, 1, 1, 0,
+	})
+
+	tests := []struct {
+		name   string
+		option func() trainer.OptionFunc
+	}{
+		{
+			name:   "seed",
+			option: func() trainer.OptionFunc { return trainer.Seed(2) },
+		},
+		{
+			name:   "rand",
+			option: func() trainer.OptionFunc { return trainer.Rand(rand.New(rand.NewSource(2))) },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			run := func() []float64 {
+				model := models.NewTwoLayerNet(2, 4, 2, matutil.NewRand(1))
+				tr := trainer.InitTrainer(
+					model, optimizers.InitAdam(0.01, 0.9, 0.999),
+					trainer.EvalInterval(1),
+					trainer.Logger(trainer.SilentLogger{}),
+					tt.option(),
+				)
+				tr.Fit(x, teacher, 3, 2)
+				return tr.LossList
+			}
+
+			want := run()
+
+			// trainers running in parallel do not share random number generator.
+			got := make([][]float64, 4)
+			var wg sync.WaitGroup
+			for i := range got {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					got[i] = run()
+				}(i)
+			}
+			wg.Wait()
+
+			for _, g := range got {
+				if !reflect.DeepEqual(want, g) {
+					t.Errorf("want = %v, got = %v", want, g)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package trainer
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/po3rin/gonnp/word"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SequenceModel is model which keeps hidden state between mini-batches like RNNLM.
+type SequenceModel interface {
+	Model
+	State() mat.Matrix
+	SetState(h mat.Matrix)
+	ResetState()
+}
+
+// FitSequence trains sequence model from corpus using Truncated BPTT.
+// corpus is cut into batchSize streams which are fed in order by timeSize,
+// so hidden state is carried over between mini-batches.
+// it panics if corpus is too short to cut a mini-batch.
+func (t *Train) FitSequence(corpus word.Corpus, maxEpoch, batchSize, timeSize int) {
+	if err := checkSequence(corpus, batchSize, timeSize); err != nil {
+		panic(err)
+	}
+
+	xs := corpus[:len(corpus)-1]
+	ts := corpus[1:]
+
+	dataSize := len(xs)
+	maxIters := int(dataSize / (batchSize * timeSize))
+	var totalLoss, epochLoss float64
+	var lossCount int
+
+	timeIdx := 0
+	if m, ok := t.Model.(SequenceModel); ok {
+		m.ResetState()
+	}
+
+	t.beginFit()
+	for i := 0; i < maxEpoch && !t.stopped(); i++ {
+		t.beginEpoch(maxIters)
+		for j := 0; j < maxIters; j++ {
+			bx := sequenceBatch(xs, batchSize, timeSize, timeIdx)
+			bt := sequenceBatch(ts, batchSize, timeSize, timeIdx)
+			timeIdx += timeSize
+
+			t.scheduleLR()
+			loss := t.Model.Forward(bt, bx)
+			t.Model.Backward()
+			t.update()
+
+			totalLoss += loss
+			epochLoss += loss
+			lossCount++
+
+			e := t.event(j, maxIters)
+			e.Loss = loss
+			if j%t.EvalInterval == 0 {
+				e.Eval = true
+				e.AvgLoss = totalLoss / float64(lossCount)
+				e.Perplexity = math.Exp(e.AvgLoss)
+				totalLoss, lossCount = 0, 0
+			}
+			t.endBatch(e)
+		}
+		t.endEpoch(epochLoss/float64(maxIters), maxIters)
+		epochLoss = 0
+	}
+	t.endFit(maxIters)
+}
+
+// checkSequence checks that at least one (batchSize, timeSize) mini-batch can be cut from corpus.
+func checkSequence(corpus word.Corpus, batchSize, timeSize int) error {
+	if batchSize <= 0 || timeSize <= 0 {
+		return errors.Errorf("gonnp: batchSize and timeSize must be positive: batchSize = %v, timeSize = %v", batchSize, timeSize)
+	}
+	if len(corpus) < batchSize*timeSize+1 {
+		return errors.Errorf("gonnp: corpus is too short: needs at least batchSize * timeSize + 1 = %v words, but has %v", batchSize*timeSize+1, len(corpus))
+	}
+	return nil
+}
+
+// sequenceBatch cuts (batchSize, timeSize) mini-batch from data.
+// i-th row starts from i * (len(data) / batchSize) + timeIdx.
+func sequenceBatch(data word.Corpus, batchSize, timeSize, timeIdx int) *mat.Dense {
+	dataSize := len(data)
+	jump := dataSize / batchSize
+
+	b := mat.NewDense(batchSize, timeSize, nil)
+	for i := 0; i < batchSize; i++ {
+		offset := i * jump
+		for j := 0; j < timeSize; j++ {
+			b.Set(i, j, data[(offset+timeIdx+j)%dataSize])
+		}
+	}
+	return b
+}
@@ -0,0 +1,166 @@
+// +build !e2e
+
+package trainer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/po3rin/gonnp/matutil"
+	"github.com/po3rin/gonnp/models"
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/schedule"
+	"github.com/po3rin/gonnp/trainer"
+	"github.com/po3rin/gonnp/word"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSequenceBatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      word.Corpus
+		batchSize int
+		timeSize  int
+		timeIdx   int
+		want      mat.Matrix
+	}{
+		{
+			name:      "first batch",
+			data:      word.Corpus{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			batchSize: 2,
+			timeSize:  3,
+			timeIdx:   0,
+			want: mat.NewDense(2, 3, []float64{
+				0, 1, 2,
+				5, 6, 7,
+			}),
+		},
+		{
+			name:      "wrap around",
+			data:      word.Corpus{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			batchSize: 2,
+			timeSize:  3,
+			timeIdx:   3,
+			want: mat.NewDense(2, 3, []float64{
+				3, 4, 5,
+				8, 9, 0,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := trainer.SequenceBatch(tt.data, tt.batchSize, tt.timeSize, tt.timeIdx)
+			if !mat.Equal(got, tt.want) {
+				t.Errorf("want = %v, got = %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFitSequence(t *testing.T) {
+	batchSize := 2
+	timeSize := 3
+	maxEpoch := 2
+
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	optimizer := optimizers.InitSDG(0.1)
+	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(1))
+
+	// checks no panic ...
+	trainer.FitSequence(corpus, maxEpoch, batchSize, timeSize)
+	if len(trainer.PplList) == 0 {
+		t.Fatal("perplexity is not recorded")
+	}
+}
+
+func TestFitSequenceWithMaxGradNorm(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	optimizer := optimizers.InitSDG(0.1)
+	tr := trainer.InitTrainer(model, optimizer, trainer.MaxGradNorm(0.25))
+
+	tr.FitSequence(corpus, 1, 2, 3)
+	if tr.GradNorm <= 0 {
+		t.Errorf("grad norm is not recorded: %v", tr.GradNorm)
+	}
+}
+
+func TestFitSequenceWithScheduler(t *testing.T) {
+	text := "You say goodbye and I say hello. You say goodbye and I say hello."
+	corpus, w2id, _ := word.PreProcess(text)
+
+	model := models.InitRNNLM(len(w2id), 5, 5, matutil.NewRand(1))
+	optimizer := optimizers.InitSDG(0.1)
+	tr := trainer.InitTrainer(
+		model, optimizer,
+		trainer.EvalInterval(1),
+		trainer.Scheduler(schedule.InitStepDecay(1, 0.5)),
+	)
+
+	tr.FitSequence(corpus, 2, 2, 3)
+
+	want := []float64{0.1, 0.1, 0.05, 0.05}
+	if len(tr.LRList) != len(want) {
+		t.Fatalf("want = %v, got = %v", want, tr.LRList)
+	}
+	for i, w := range want {
+		if math.Abs(tr.LRList[i]-w) > 1e-12 {
+			t.Errorf("want = %v, got = %v", want, tr.LRList)
+		}
+	}
+	if tr.CurrentIter != 4 {
+		t.Errorf("iter: want = 4, got = %v", tr.CurrentIter)
+	}
+}
+
+func TestFitSequencePanic(t *testing.T) {
+	tests := []struct {
+		name      string
+		corpus    word.Corpus
+		batchSize int
+		timeSize  int
+	}{
+		{
+			name:      "empty corpus",
+			corpus:    word.Corpus{},
+			batchSize: 2,
+			timeSize:  3,
+		},
+		{
+			name:      "too short corpus",
+			corpus:    word.Corpus{0, 1, 2, 3, 4, 5},
+			batchSize: 2,
+			timeSize:  3,
+		},
+		{
+			name:      "zero time size",
+			corpus:    word.Corpus{0, 1, 2, 3, 4, 5},
+			batchSize: 2,
+			timeSize:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := &constModel{}
+			tr := trainer.InitTrainer(m, optimizers.InitSDG(0.1), trainer.Logger(nil))
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+				if m.forward != 0 {
+					t.Errorf("forward: want = 0, got = %v", m.forward)
+				}
+			}()
+			tr.FitSequence(tt.corpus, 1, tt.batchSize, tt.timeSize)
+		})
+	}
+}
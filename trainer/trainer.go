@@ -2,12 +2,14 @@
 package trainer
 
 import (
-	"fmt"
 	"math/rand"
+	"os"
 	"time"
 
+	"github.com/po3rin/gonnp/dataset"
 	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/schedule"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -23,13 +25,39 @@ type Optimizer interface {
 	Update(params []params.Param, grads []params.Grad) []params.Param
 }
 
+// LRManager is optimizer which manages learning rate.
+type LRManager interface {
+	GetLR() float64
+	SetLR(lr float64)
+}
+
 // Train has trainer config.
 type Train struct {
-	Model        Model
-	Optimizer    Optimizer
-	LossList     []float64
-	EvalInterval int
-	CurrentEpoch float64
+	Model            Model
+	Optimizer        Optimizer
+	LossList         []float64
+	LRList           []float64
+	PplList          []float64
+	ValidList        []float64
+	EvalInterval     int
+	CurrentEpoch     float64
+	CurrentIter      int
+	Validator        Validator
+	Logger           Callback
+	Callbacks        []Callback
+	MaxGradNorm      float64
+	GradNorm         float64
+	Scheduler        schedule.Scheduler
+	CheckpointFile   string
+	EarlyStop        *EarlyStop
+	Metrics          []Metric
+	Shuffle          bool
+	KeepPartialBatch bool
+	Rand             *rand.Rand
+	ValidBatchSize   int
+	History          History
+	initLR           float64
+	validData        *dataset.Matrix
 }
 
 // OptionFunc for set option for trainer
@@ -42,65 +70,149 @@ func EvalInterval(i int) func(*Train) {
 	}
 }
 
+// MaxGradNorm sets MaxGradNorm option. grads are clipped by global L2 norm if it is positive.
+func MaxGradNorm(n float64) func(*Train) {
+	return func(t *Train) {
+		t.MaxGradNorm = n
+	}
+}
+
+// Scheduler sets learning rate scheduler. optimizer should implement LRManager.
+func Scheduler(s schedule.Scheduler) func(*Train) {
+	return func(t *Train) {
+		t.Scheduler = s
+	}
+}
+
+// Seed sets random number generator of shuffling seeded with seed. default is seeded by current time.
+// weights & negative samples are drawn by generator passed to model constructor,
+// so create model with generator of same seed to make training reproducible.
+func Seed(seed int64) func(*Train) {
+	return func(t *Train) {
+		t.Rand = matutil.NewRand(seed)
+	}
+}
+
+// Rand sets random number generator of shuffling. it can be shared with model like matutil.NewRand(seed).
+func Rand(r *rand.Rand) func(*Train) {
+	return func(t *Train) {
+		t.Rand = r
+	}
+}
+
 // InitTrainer inits Trainer.
 func InitTrainer(model Model, opt Optimizer, options ...OptionFunc) *Train {
 	t := &Train{
 		Model:     model,
 		Optimizer: opt,
 		// set default value.
-		EvalInterval: 20,
+		EvalInterval:   20,
+		Logger:         NewTextLogger(os.Stdout),
+		Shuffle:        true,
+		ValidBatchSize: 100,
+		Rand:           matutil.NewRand(time.Now().UnixNano()),
 	}
 
 	for _, option := range options {
 		option(t)
 	}
 
+	if t.validData != nil {
+		if t.Validator != nil {
+			panic("gonnp: Validation & ValidationData can not be used together")
+		}
+		if t.ValidBatchSize <= 0 {
+			panic("gonnp: validation batch size should be positive")
+		}
+		t.Validator = t.validationLoss
+	}
+	if t.EarlyStop != nil {
+		t.EarlyStop.direction = t.monitorDirection(t.EarlyStop.Monitor)
+	}
+
 	return t
 }
 
 // Fit traims from data.
+// rows are shuffled every epoch unless Shuffle(false) is set. mini-batches are prepared in background.
 func (t *Train) Fit(x mat.Matrix, teacher mat.Matrix, maxEpoch, batchSize int) {
-	dataSize, c := x.Dims()
-	_, tc := teacher.Dims()
-
-	maxIters := int(dataSize / batchSize)
-	var totalLoss float64
-	var lossCount int
+	l := t.newLoader(dataset.NewMatrix(x, teacher), batchSize)
+	defer l.Close()
 
-	for i := 0; i < maxEpoch; i++ {
-		// shuffle
-		rand.Seed(time.Now().UnixNano())
-		idx := rand.Perm(dataSize)
-
-		dx := matutil.ThinRow(x, idx)
-		dt := matutil.ThinRow(teacher, idx)
+	t.FitLoader(l, maxEpoch)
+}
 
-		for j := 0; j < maxIters; j++ {
-			bx := dx.Slice(j*batchSize, (j+1)*batchSize, 0, c)
-			bt := dt.Slice(j*batchSize, (j+1)*batchSize, 0, tc)
+// newLoader inits loader of ds with options of trainer.
+func (t *Train) newLoader(ds dataset.Dataset, batchSize int) *dataset.Loader {
+	return dataset.NewLoader(
+		ds, batchSize,
+		dataset.Shuffle(t.Shuffle),
+		dataset.KeepPartialBatch(t.KeepPartialBatch),
+		dataset.Rand(t.Rand),
+	)
+}
 
-			loss := t.Model.Forward(bt, bx)
-			t.Model.Backward()
+// update updates model params using grads.
+// if MaxGradNorm is set, grads are clipped and GradNorm keeps the norm before clipping.
+func (t *Train) update() {
+	params := t.Model.GetParams()
+	grads := t.Model.GetGrads()
 
-			params := t.Model.GetParams()
-			grads := t.Model.GetGrads()
+	params, grads = rmDuplicate(params, grads)
 
-			params, grads = rmDuplicate(params, grads)
+	if t.MaxGradNorm > 0 {
+		t.GradNorm = ClipGrads(grads, t.MaxGradNorm)
+	}
 
-			params = t.Optimizer.Update(params, grads)
+	params = t.Optimizer.Update(params, grads)
+	t.Model.UpdateParams(params)
+	t.CurrentIter++
+}
 
-			t.Model.UpdateParams(params)
+// scheduleLR sets learning rate of optimizer using Scheduler.
+func (t *Train) scheduleLR() {
+	if t.Scheduler == nil {
+		return
+	}
+	m, ok := t.Optimizer.(LRManager)
+	if !ok {
+		panic("gonnp: optimizer does not support learning rate scheduling")
+	}
+	if t.initLR == 0 {
+		t.initLR = m.GetLR()
+	}
+	m.SetLR(t.Scheduler.LR(t.initLR, int(t.CurrentEpoch), t.CurrentIter))
+}
 
-			totalLoss += loss
-			lossCount++
+// recordLR records current learning rate if optimizer has it.
+func (t *Train) recordLR() {
+	if m, ok := t.Optimizer.(LRManager); ok {
+		t.LRList = append(t.LRList, m.GetLR())
+	}
+}
 
-			if j%t.EvalInterval == 0 {
-				avgLoss := totalLoss / float64(lossCount)
-				fmt.Printf("| epoch %v |  iter %v / %v | loss %.4f\n", t.CurrentEpoch, j, maxIters, avgLoss)
-				t.LossList = append(t.LossList, float64(avgLoss))
-				totalLoss, lossCount = 0, 0
-			}
+// endEpoch runs validation, passes metric of the epoch to Scheduler, calls OnEpochEnd of callbacks & saves checkpoint.
+// metric is validation result if Validator is set, otherwise average loss.
+func (t *Train) endEpoch(loss float64, maxIters int) {
+	e := t.event(0, maxIters)
+	e.Loss = loss
+	e.Valid, e.HasValid = t.validate()
+	e.Metrics = t.evalMetrics()
+	t.History.record(e)
+
+	if o, ok := t.Scheduler.(schedule.Observer); ok {
+		metric := loss
+		if e.HasValid {
+			metric = e.Valid
 		}
-		t.CurrentEpoch++
+		o.Observe(metric)
+	}
+	if t.EarlyStop != nil {
+		t.EarlyStop.observe(e, t.Model)
+	}
+	for _, c := range t.callbacks() {
+		c.OnEpochEnd(e)
 	}
+	t.CurrentEpoch++
+	t.saveCheckpoint(e)
 }
@@ -6,13 +6,14 @@ import (
 	"testing"
 
 	"github.com/po3rin/gomnist"
+	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/models"
 	"github.com/po3rin/gonnp/optimizers"
 	"github.com/po3rin/gonnp/trainer"
 )
 
 func TestFit(t *testing.T) {
-	model := models.NewTwoLayerNet(784, 50, 10)
+	model := models.NewTwoLayerNet(784, 50, 10, matutil.NewRand(1))
 	optimizer := optimizers.InitSDG(0.01)
 	trainer := trainer.InitTrainer(model, optimizer, trainer.EvalInterval(10))
 
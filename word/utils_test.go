@@ -73,7 +73,9 @@ anybody: 0.5758252412919126
 	for _, tt := range tests {
 		t.Run(tt.query, func(t *testing.T) {
 			cbow := &store.CBOW{}
-			cbow.Decode("./../testdata/cbow.gob")
+			if err := cbow.Decode("./../testdata/cbow.gob"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 
 			stdout := new(bytes.Buffer)
 			word.WriteMostSimilar(stdout, tt.query, cbow.W2ID, cbow.ID2W, cbow.WordVecs)
@@ -2,13 +2,10 @@
 package xtrainer
 
 import (
-	"fmt"
 	"math/rand"
-	"reflect"
-	"time"
 
-	"github.com/po3rin/gonnp/matutil"
 	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/trainer"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -24,13 +21,10 @@ type Optimizer interface {
 	Update(params []params.Param, grads []params.Grad) []params.Param
 }
 
-// Train has trainer config.
+// Train has trainer config. training loop is shared with trainer.Train.
 type Train struct {
-	Model        Model
-	Optimizer    Optimizer
-	LossList     []float64
-	EvalInterval int
-	CurrentEpoch float64
+	*trainer.Train
+	Model Model
 }
 
 // OptionFunc for set option for trainer
@@ -39,115 +33,92 @@ type OptionFunc func(t *Train)
 // EvalInterval sets EvalInterval option.
 func EvalInterval(i int) func(*Train) {
 	return func(t *Train) {
-		t.EvalInterval = i
+		trainer.EvalInterval(i)(t.Train)
 	}
 }
 
-// InitTrainer inits Trainer.
-func InitTrainer(model Model, opt Optimizer, options ...OptionFunc) *Train {
-	t := &Train{
-		Model:     model,
-		Optimizer: opt,
-		// set default value.
-		EvalInterval: 20,
+// Logger sets logger of training. default logger is trainer.TextLogger which writes to stdout.
+func Logger(l trainer.Callback) func(*Train) {
+	return func(t *Train) {
+		trainer.Logger(l)(t.Train)
 	}
+}
 
-	for _, option := range options {
-		option(t)
+// Callbacks adds callbacks which are called after logger.
+func Callbacks(cs ...trainer.Callback) func(*Train) {
+	return func(t *Train) {
+		trainer.Callbacks(cs...)(t.Train)
 	}
-
-	return t
 }
 
-// Fit traims from data.
-func (t *Train) Fit(x mat.Matrix, teacher mat.Matrix, maxEpoch, batchSize int) {
-	dataSize, c := x.Dims()
-	_, tc := teacher.Dims()
-
-	maxIters := int(dataSize / batchSize)
-	var totalLoss float64
-	var lossCount int
-
-	for i := 0; i < maxEpoch; i++ {
-		// shuffle
-		rand.Seed(time.Now().UnixNano())
-		idx := rand.Perm(dataSize)
-
-		dx := matutil.ThinRow(x, idx)
-		dt := matutil.ThinRow(teacher, idx)
-
-		for j := 0; j < maxIters; j++ {
-			bx := dx.Slice(j*batchSize, (j+1)*batchSize, 0, c)
-			bt := dt.Slice(j*batchSize, (j+1)*batchSize, 0, tc)
-
-			bxc := make(chan mat.Matrix, 1)
-			btc := make(chan mat.Matrix, 1)
-			lossc := make(chan float64, 1)
-
-			go t.Model.Forward(lossc, btc, bxc)
-
-			bxc <- bx
-			btc <- bt
-			loss := <-lossc
+// Shuffle sets whether data is shuffled every epoch. default is true.
+func Shuffle(b bool) func(*Train) {
+	return func(t *Train) {
+		trainer.Shuffle(b)(t.Train)
+	}
+}
 
-			out := make(chan mat.Matrix, 1)
-			go t.Model.Backward(out)
-			<-out
+// KeepPartialBatch sets whether the last mini-batch smaller than batch size is used. default is false.
+func KeepPartialBatch(b bool) func(*Train) {
+	return func(t *Train) {
+		trainer.KeepPartialBatch(b)(t.Train)
+	}
+}
 
-			params := t.Model.GetParams()
-			grads := t.Model.GetGrads()
+// Seed sets random number generator of shuffling seeded with seed. default is seeded by current time.
+// weights & negative samples are drawn by generator passed to model constructor,
+// so create model with generator of same seed to make training reproducible.
+func Seed(seed int64) func(*Train) {
+	return func(t *Train) {
+		trainer.Seed(seed)(t.Train)
+	}
+}
 
-			params, grads = rmDuplicate(params, grads)
+// Rand sets random number generator of shuffling. it can be shared with model like matutil.NewRand(seed).
+func Rand(r *rand.Rand) func(*Train) {
+	return func(t *Train) {
+		trainer.Rand(r)(t.Train)
+	}
+}
 
-			params = t.Optimizer.Update(params, grads)
+// InitTrainer inits Trainer.
+func InitTrainer(model Model, opt Optimizer, options ...OptionFunc) *Train {
+	t := &Train{
+		Train: trainer.InitTrainer(chanModel{model}, opt),
+		Model: model,
+	}
 
-			t.Model.UpdateParams(params)
+	for _, option := range options {
+		option(t)
+	}
 
-			totalLoss += loss
-			lossCount++
+	return t
+}
 
-			if j%t.EvalInterval == 0 {
-				avgLoss := totalLoss / float64(lossCount)
-				fmt.Printf("| epoch %v |  iter %v / %v | loss %.4f\n", t.CurrentEpoch, j, maxIters, avgLoss)
-				t.LossList = append(t.LossList, float64(avgLoss))
-				totalLoss, lossCount = 0, 0
-			}
-		}
-		t.CurrentEpoch++
-	}
+// chanModel adapts Model which communicates through channels to trainer.Model.
+type chanModel struct {
+	Model
 }
 
-func rmDuplicate(params []params.Param, grads []params.Grad) ([]params.Param, []params.Grad) {
-	for {
-		var findFlg bool
-		L := len(params)
-		for i := 0; i < L; i++ {
-			for j := i + 1; j < L; j++ {
-				if reflect.DeepEqual(params[i].Weight, params[j].Weight) {
-					r, c := grads[i].Weight.Dims()
-					d := mat.NewDense(r, c, nil)
-					d.Add(grads[i].Weight, grads[j].Weight)
-					grads[i].Weight = d
-					findFlg = true
-					params = append(params[:j], params[j+1:]...)
-					grads = append(grads[:j], grads[j+1:]...)
-				}
-				if findFlg {
-					break
-				}
-			}
-			if findFlg {
-				break
-			}
-		}
-		if !findFlg {
-			break
-		}
+// Forward sends teacher & x to model and waits loss.
+func (m chanModel) Forward(teacher mat.Matrix, x ...mat.Matrix) float64 {
+	if len(x) != 1 {
+		panic("gonnp: xtrainer model takes one input matrix")
 	}
-	return params, grads
+	bxc := make(chan mat.Matrix, 1)
+	btc := make(chan mat.Matrix, 1)
+	lossc := make(chan float64, 1)
+
+	go m.Model.Forward(lossc, btc, bxc)
+
+	bxc <- x[0]
+	btc <- teacher
+	return <-lossc
 }
 
-// GetWordDist returns Words Distributed representation.
-func (t *Train) GetWordDist() mat.Matrix {
-	return t.Model.GetParams()[0].Weight
+// Backward waits backward of model.
+func (m chanModel) Backward() mat.Matrix {
+	out := make(chan mat.Matrix, 1)
+	go m.Model.Backward(out)
+	return <-out
 }
@@ -0,0 +1,80 @@
+// +build !e2e
+
+package xtrainer_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/po3rin/gonnp/optimizers"
+	"github.com/po3rin/gonnp/params"
+	"github.com/po3rin/gonnp/x/xtrainer"
+	"gonum.org/v1/gonum/mat"
+)
+
+// chanModel records first column of each mini-batch.
+type chanModel struct {
+	batches [][]float64
+	w       *mat.Dense
+}
+
+func (m *chanModel) Forward(out chan<- float64, in ...<-chan mat.Matrix) {
+	<-in[0]
+	x := <-in[1]
+	m.batches = append(m.batches, mat.Col(nil, 0, x))
+	out <- 1
+}
+
+func (m *chanModel) Backward(out chan<- mat.Matrix) {
+	out <- nil
+}
+
+func (m *chanModel) GetParams() []params.Param {
+	return []params.Param{{Weight: m.w}}
+}
+
+func (m *chanModel) GetGrads() []params.Grad {
+	return []params.Grad{{Weight: mat.NewDense(1, 1, nil)}}
+}
+
+func (m *chanModel) UpdateParams(ps []params.Param) {
+	m.w = ps[0].Weight.(*mat.Dense)
+}
+
+func TestFit(t *testing.T) {
+	x := mat.NewDense(5, 1, []float64{0, 1, 2, 3, 4})
+
+	tests := []struct {
+		name    string
+		options []xtrainer.OptionFunc
+		want    [][]float64
+	}{
+		{
+			name:    "drop partial batch",
+			options: []xtrainer.OptionFunc{xtrainer.Shuffle(false)},
+			want:    [][]float64{{0, 1}, {2, 3}},
+		},
+		{
+			name:    "keep partial batch",
+			options: []xtrainer.OptionFunc{xtrainer.Shuffle(false), xtrainer.KeepPartialBatch(true)},
+			want:    [][]float64{{0, 1}, {2, 3}, {4}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := &chanModel{w: mat.NewDense(1, 1, nil)}
+			options := append([]xtrainer.OptionFunc{xtrainer.Logger(nil), xtrainer.EvalInterval(1)}, tt.options...)
+			tr := xtrainer.InitTrainer(m, optimizers.InitSDG(0.1), options...)
+			tr.Fit(x, x, 1, 2)
+
+			if !reflect.DeepEqual(m.batches, tt.want) {
+				t.Errorf("want = %v, got = %v", tt.want, m.batches)
+			}
+			if len(tr.LossList) != len(tt.want) {
+				t.Errorf("want = %v, got = %v", len(tt.want), tr.LossList)
+			}
+		})
+	}
+}